@@ -0,0 +1,128 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// lockedReader serializes reads from r, so that a reader which is not safe
+// for concurrent use can be shared by goroutines.
+type lockedReader struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+func (l *lockedReader) Read(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Read(p)
+}
+
+// GenerateKeyPairs generates n independent key pairs, as if by calling
+// GenerateKeyPair n times. Key pairs are generated concurrently by up to
+// GOMAXPROCS goroutines, which share rand. If rand is nil, crypto/rand.Reader
+// is used.
+//
+// GenerateKeyPairs returns the first error encountered, in which case no
+// keys are returned.
+func GenerateKeyPairs(rand io.Reader, n int) (publicKeys, privateKeys [][]byte, err error) {
+	if n < 0 {
+		return nil, nil, errors.New("dhgroup14: negative number of key pairs")
+	}
+	r := &lockedReader{r: randReader(rand)}
+	publicKeys = make([][]byte, n)
+	privateKeys = make([][]byte, n)
+	err = runBatch(n, func(ctx context.Context, i int) (err error) {
+		publicKeys[i], privateKeys[i], err = GenerateKeyPairContext(ctx, r)
+		return err
+	})
+	if err != nil {
+		for _, priv := range privateKeys {
+			Zeroize(priv)
+		}
+		return nil, nil, err
+	}
+	return publicKeys, privateKeys, nil
+}
+
+// SharedKeyBatch computes shared keys between each of theirPublicKeys and
+// myPrivateKey, as if by calling SharedKey for each of them, and returns
+// them in the same order. Each public key is validated. Shared keys are
+// computed concurrently by up to GOMAXPROCS goroutines, which share rand. If
+// rand is nil, crypto/rand.Reader is used.
+//
+// SharedKeyBatch fails fast: it returns the first error encountered, which
+// identifies the index of the offending public key and wraps the error
+// returned by SharedKey, and no shared keys.
+//
+// There is no precomputation shared between entries: the exponent is the
+// same, but math/big exponentiation only benefits from precomputation for a
+// fixed base, and blinding randomizes the exponent of every computation.
+func SharedKeyBatch(rand io.Reader, theirPublicKeys [][]byte, myPrivateKey []byte) ([][]byte, error) {
+	r := &lockedReader{r: randReader(rand)}
+	sharedKeys := make([][]byte, len(theirPublicKeys))
+	err := runBatch(len(theirPublicKeys), func(ctx context.Context, i int) error {
+		sharedKey, err := SharedKeyContext(ctx, r, theirPublicKeys[i], myPrivateKey)
+		if err != nil {
+			return fmt.Errorf("dhgroup14: public key %d: %w", i, err)
+		}
+		sharedKeys[i] = sharedKey
+		return nil
+	})
+	if err != nil {
+		for _, sharedKey := range sharedKeys {
+			Zeroize(sharedKey)
+		}
+		return nil, err
+	}
+	return sharedKeys, nil
+}
+
+// runBatch calls f for indices 0 to n-1 concurrently by up to GOMAXPROCS
+// goroutines. On the first error, it cancels the context passed to f, stops
+// starting new calls, and returns that error once running calls finish.
+func runBatch(n int, f func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	jobs := make(chan int)
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := f(ctx, i); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return firstErr
+}
@@ -0,0 +1,155 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestGenerateKeyPairs(t *testing.T) {
+	const n = 5
+	publicKeys, privateKeys, err := GenerateKeyPairs(rand.Reader, n)
+	if err != nil {
+		t.Fatalf("generate key pairs: %s", err)
+	}
+	if len(publicKeys) != n || len(privateKeys) != n {
+		t.Fatalf("generated %d public and %d private keys, expected %d", len(publicKeys), len(privateKeys), n)
+	}
+	for i := range privateKeys {
+		publicKey, err := GeneratePublicKeyDeterministic(privateKeys[i])
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if !bytes.Equal(publicKey, publicKeys[i]) {
+			t.Fatalf("%d: public key doesn't match private key", i)
+		}
+		for j := 0; j < i; j++ {
+			if bytes.Equal(privateKeys[i], privateKeys[j]) {
+				t.Fatalf("private keys %d and %d are equal", i, j)
+			}
+		}
+	}
+	if publicKeys, privateKeys, err := GenerateKeyPairs(rand.Reader, 0); err != nil || len(publicKeys) != 0 || len(privateKeys) != 0 {
+		t.Fatalf("zero key pairs: %v, %v, %v", publicKeys, privateKeys, err)
+	}
+	if _, _, err := GenerateKeyPairs(rand.Reader, -1); err == nil {
+		t.Fatalf("accepted negative number of key pairs")
+	}
+}
+
+func TestGenerateKeyPairsError(t *testing.T) {
+	// Enough randomness for one or two key pairs.
+	r := io.MultiReader(io.LimitReader(rand.Reader, 3*PrivateKeySize), errorReader{})
+	publicKeys, privateKeys, err := GenerateKeyPairs(r, 10)
+	if !errors.Is(err, errRead) {
+		t.Fatalf("expected %v, got %v", errRead, err)
+	}
+	if publicKeys != nil || privateKeys != nil {
+		t.Fatalf("returned keys on error")
+	}
+}
+
+func TestSharedKeyBatch(t *testing.T) {
+	const n = 4
+	publicKeys, _, err := GenerateKeyPairs(rand.Reader, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, privateKey, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sharedKeys, err := SharedKeyBatch(rand.Reader, publicKeys, privateKey)
+	if err != nil {
+		t.Fatalf("shared key batch: %s", err)
+	}
+	if len(sharedKeys) != n {
+		t.Fatalf("computed %d shared keys, expected %d", len(sharedKeys), n)
+	}
+	for i := range publicKeys {
+		sharedKey, err := SharedKey(rand.Reader, publicKeys[i], privateKey)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if !bytes.Equal(sharedKey, sharedKeys[i]) {
+			t.Fatalf("%d: batch shared key doesn't match SharedKey", i)
+		}
+	}
+
+	publicKeys[2] = make([]byte, PublicKeySize)
+	sharedKeys, err = SharedKeyBatch(rand.Reader, publicKeys, privateKey)
+	if !errors.Is(err, ErrDegeneratePublicKey) || !strings.Contains(err.Error(), "public key 2") {
+		t.Fatalf("expected error for public key 2, got %v", err)
+	}
+	if sharedKeys != nil {
+		t.Fatalf("returned shared keys on error")
+	}
+}
+
+var errRead = errors.New("read error")
+
+type errorReader struct{}
+
+func (errorReader) Read(p []byte) (int, error) { return 0, errRead }
+
+func BenchmarkGenerateKeyPairsSerial(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 16; j++ {
+			if _, _, err := GenerateKeyPair(rand.Reader); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkGenerateKeyPairsBatch(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, _, err := GenerateKeyPairs(rand.Reader, 16); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkSharedKeyBatchKeys(b *testing.B) ([][]byte, []byte) {
+	publicKeys, _, err := GenerateKeyPairs(rand.Reader, 16)
+	if err != nil {
+		b.Fatal(err)
+	}
+	_, privateKey, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return publicKeys, privateKey
+}
+
+func BenchmarkSharedKeyBatchSerial(b *testing.B) {
+	publicKeys, privateKey := benchmarkSharedKeyBatchKeys(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, publicKey := range publicKeys {
+			if _, err := SharedKey(rand.Reader, publicKey, privateKey); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkSharedKeyBatch(b *testing.B) {
+	publicKeys, privateKey := benchmarkSharedKeyBatchKeys(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := SharedKeyBatch(rand.Reader, publicKeys, privateKey); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
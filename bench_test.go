@@ -0,0 +1,54 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func BenchmarkGenerateKeyPair(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := GenerateKeyPair(rand.Reader); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGeneratePublicKey(b *testing.B) {
+	_, privateKey, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := GeneratePublicKey(rand.Reader, privateKey); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSharedKey(b *testing.B) {
+	publicKey, _, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	_, privateKey, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := SharedKey(rand.Reader, publicKey, privateKey); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
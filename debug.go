@@ -0,0 +1,61 @@
+//go:build dhgroup14debug
+
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"crypto/sha256"
+	"io"
+	"log"
+	"sync"
+)
+
+var blindingCheck struct {
+	mu      sync.Mutex
+	last    [sha256.Size]byte
+	handler func()
+}
+
+// checkBlinding calls the blinding repeat handler if b is the same as the
+// previous blinding value. Only a hash of the previous value is kept.
+func checkBlinding(b []byte) {
+	h := sha256.Sum256(b)
+	blindingCheck.mu.Lock()
+	repeated := h == blindingCheck.last
+	blindingCheck.last = h
+	handler := blindingCheck.handler
+	blindingCheck.mu.Unlock()
+	if !repeated {
+		return
+	}
+	if handler != nil {
+		handler()
+	} else {
+		log.Print("dhgroup14: blinding value repeated; random source is broken")
+	}
+}
+
+// checkRand logs a warning if WarnIfInsecureRand reports that rand is
+// insecure.
+func checkRand(rand io.Reader) {
+	if err := WarnIfInsecureRand(rand); err != nil {
+		log.Print(err)
+	}
+}
+
+// SetBlindingRepeatHandler sets the function called when the same blinding
+// value is read twice in a row, which indicates a broken random source. If
+// handler is nil, a warning is logged with the log package.
+//
+// This build has the dhgroup14debug build tag, so detection is enabled.
+func SetBlindingRepeatHandler(handler func()) {
+	blindingCheck.mu.Lock()
+	defer blindingCheck.mu.Unlock()
+	blindingCheck.handler = handler
+}
@@ -0,0 +1,53 @@
+//go:build dhgroup14debug
+
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+// constantReader returns the same bytes on every read.
+type constantReader struct{}
+
+func (constantReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0x42
+	}
+	return len(p), nil
+}
+
+func TestBlindingRepeat(t *testing.T) {
+	repeats := 0
+	SetBlindingRepeatHandler(func() { repeats++ })
+	defer SetBlindingRepeatHandler(nil)
+
+	for i := 0; i < 3; i++ {
+		if _, err := SharedKey(rand.Reader, golden.publicKey1, golden.privateKey2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if repeats != 0 {
+		t.Fatalf("detected %d repeats with crypto/rand", repeats)
+	}
+	for i := 0; i < 3; i++ {
+		sharedKey, err := SharedKey(constantReader{}, golden.publicKey1, golden.privateKey2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(sharedKey, golden.sharedKey) {
+			t.Fatalf("wrong shared key")
+		}
+	}
+	if repeats != 2 {
+		t.Fatalf("detected %d repeats, expected 2", repeats)
+	}
+}
@@ -11,12 +11,45 @@
 //
 // This is the same algorithm used by libcperciva (Tarsnap, spipe, etc.)
 // See http://mail.tarsnap.com/spiped/msg00071.html for details.
+//
+// Package-level functions use DefaultGroup, which is group #14 unless
+// changed at startup. Other groups are available as Group values: Group15,
+// Group16 and Group18 from RFC 3526, and FFDHE2048 from RFC 7919. Other
+// MODP groups can be used by constructing a Group with NewGroup, which checks
+// that their parameters are consistent.
+//
+// Keys are big-endian byte strings, as in RFC 3526 and spiped: the first
+// byte is the most significant. Only GeneratePublicKeyLE and SharedKeyLE
+// take and return little-endian keys.
+//
+// Randomness consumption is fixed: generating a key pair reads exactly
+// 2*DefaultGroup.PrivateKeySize bytes from the random source (the private
+// key and the blinding value), while computing a public key or a shared key
+// reads exactly DefaultGroup.PrivateKeySize bytes (the blinding value). For
+// group #14 that is 64 and 32 bytes; Group methods read g.PrivateKeySize
+// bytes per value instead. Building with the
+// dhgroup14debug tag enables detection of random sources that repeat
+// blinding values; see SetBlindingRepeatHandler.
+//
+// New code should use GenerateKey and the PrivateKey and PublicKey types,
+// which prevent passing a public key where a private key is expected.
+// Functions operating on byte slices (GenerateKeyPair, GeneratePublicKey and
+// SharedKey) are kept for compatibility.
 package dhgroup14
 
 import (
+	"context"
+	"crypto/hkdf"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
+	"sync"
+	"time"
 )
 
 const (
@@ -25,6 +58,39 @@ const (
 	SharedKeySize  = 256 // shared key size in bytes
 )
 
+// Errors returned by this package.
+var (
+	ErrWrongPrivateKeySize    = errors.New("dhgroup14: wrong private key size")
+	ErrWrongPublicKeySize     = errors.New("dhgroup14: wrong public key size")
+	ErrWrongSharedKeySize     = errors.New("dhgroup14: wrong shared key size")
+	ErrWeakPrivateKey         = errors.New("dhgroup14: private key is weak")
+	ErrPublicKeyOutOfRange    = errors.New("dhgroup14: public key is out of range")
+	ErrPublicKeyTooSmall      = errors.New("dhgroup14: public key is too small")
+	ErrDegeneratePublicKey    = errors.New("dhgroup14: public key is degenerate")
+	ErrPublicKeyNotInSubgroup = errors.New("dhgroup14: public key is not in subgroup")
+	ErrSmallPowerPublicKey    = errors.New("dhgroup14: public key is a small power of the generator")
+	ErrDegenerateResult       = errors.New("dhgroup14: result is degenerate")
+	ErrResultTooLarge         = errors.New("dhgroup14: result is too large")
+)
+
+// ErrPublicKeyTooLarge is the former name of ErrPublicKeyOutOfRange.
+//
+// Deprecated: Use ErrPublicKeyOutOfRange.
+var ErrPublicKeyTooLarge = ErrPublicKeyOutOfRange
+
+// ErrRandomnessFailed is wrapped by errors returned when reading from the
+// random source fails or returns too few bytes, or when a generated private
+// key is all zero or all 0xff bytes, which indicates a broken source. The
+// underlying error, such as ErrWeakPrivateKey, is wrapped as well. The
+// operation had no effect and may be retried once the source is available;
+// see RandomnessAvailable.
+var ErrRandomnessFailed = errors.New("dhgroup14: randomness source failed")
+
+// modulus, generator and one are shared by all goroutines and must never be
+// modified, not even temporarily: math/big only reads the operands of Exp,
+// Cmp and the like, which is what makes concurrent calls safe. Code that
+// needs a modified value must work on a copy, as Modulus does for callers.
+// SelfCheck detects a modified modulus or generator.
 var modulus = new(big.Int).SetBytes([]byte{
 	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xc9, 0x0f, 0xda, 0xa2,
 	0x21, 0x68, 0xc2, 0x34, 0xc4, 0xc6, 0x62, 0x8b, 0x80, 0xdc, 0x1c, 0xd1,
@@ -50,91 +116,399 @@ var modulus = new(big.Int).SetBytes([]byte{
 	0xff, 0xff, 0xff, 0xff,
 })
 
-var generator = big.NewInt(2)
-var twoExp256 = new(big.Int).Exp(generator, big.NewInt(256), nil) // 2^256
+// Group14 is the 2048-bit MODP group #14 from RFC 3526.
+var Group14 = newGroup("RFC 3526 MODP 2048 (group 14)", "RFC 3526", modulus)
+
+// DefaultGroup is the group used by the package-level functions that operate
+// on byte slices of group-dependent size: GenerateKeyPair, GeneratePublicKey,
+// SharedKey, ValidatePublicKey and their variants, VerifyKeyPair, and the
+// functions and types built on them, such as DeriveKey, Agree, Ephemeral,
+// StaticKey, GroupAgreement, GenerateKeyPairs, NewPeerKey, SharedKeyTruncated
+// and ParsePublicKey. Setting it to another group, such as Group16, switches
+// all of them at once. The size constants, typed keys, arrays, the other
+// encodings, Handshake, SelfTest and TestVectors always use Group14.
+//
+// DefaultGroup must be set at most once, at program startup before any
+// other use of this package, and must not be nil. It is not safe to change
+// it concurrently with other calls.
+var DefaultGroup = Group14
+
+var (
+	generator = big.NewInt(2)
+	one       = big.NewInt(1)
+)
+
+// Modulus returns a copy of the group #14 modulus.
+func Modulus() *big.Int {
+	return new(big.Int).Set(modulus)
+}
+
+// ModulusBytes returns the group #14 modulus as a PublicKeySize-byte
+// big-endian value.
+func ModulusBytes() []byte {
+	return modulus.FillBytes(make([]byte, PublicKeySize))
+}
+
+// Generator returns a copy of the group #14 generator.
+func Generator() *big.Int {
+	return new(big.Int).Set(generator)
+}
+
+// SubgroupOrder returns a copy of q = (modulus-1)/2. The group #14 modulus
+// is a safe prime, so q is prime and is the order of the subgroup of
+// quadratic residues, which is generated by the generator and contains all
+// valid public keys.
+func SubgroupOrder() *big.Int {
+	return Group14.SubgroupOrder()
+}
+
+// RandomnessAvailable reads a byte from crypto/rand.Reader and returns an
+// error wrapping ErrRandomnessFailed if that fails. It can be called at
+// startup to fail fast on systems where the random source is not ready,
+// rather than in the middle of a key exchange.
+func RandomnessAvailable() error {
+	var b [1]byte
+	if _, err := io.ReadFull(cryptorand.Reader, b[:]); err != nil {
+		return fmt.Errorf("%w: %w", ErrRandomnessFailed, err)
+	}
+	return nil
+}
+
+// OperationCost returns the measured time of one SharedKey call in
+// DefaultGroup, to help size worker pools: a server can handle roughly
+// runtime.NumCPU()/OperationCost() handshakes per second. The cost is
+// measured on the first call, not at startup. See Group.OperationCost.
+func OperationCost() time.Duration {
+	return DefaultGroup.OperationCost()
+}
+
+// Info returns a description of DefaultGroup, such as for logging which
+// parameters are in use. See Group.Info.
+func Info() GroupInfo {
+	return DefaultGroup.Info()
+}
+
+// Fingerprint returns an 8-byte identifier of the parameters of
+// DefaultGroup, for checking that peers use the same group. See
+// Group.Fingerprint.
+func Fingerprint() [8]byte {
+	return DefaultGroup.Fingerprint()
+}
 
 // GenerateKeyPair generates new random private key and the corresponding public key.
+//
+// Random bytes for the private key and for blinding are read from rand, which
+// must be set to a CSPRNG, such as crypto/rand.Reader. If rand is nil,
+// crypto/rand.Reader is used.
+//
+// New code should use GenerateKey.
 func GenerateKeyPair(rand io.Reader) (publicKey, privateKey []byte, err error) {
-	// Generate random private key.
-	privateKey = make([]byte, PrivateKeySize)
-	if _, err := io.ReadFull(rand, privateKey); err != nil {
-		return nil, nil, err
+	return DefaultGroup.GenerateKeyPair(rand)
+}
+
+// GenerateKeyPairContext is like GenerateKeyPair, but returns ctx.Err() if
+// ctx is done before or during exponentiation.
+// A Tracer attached to ctx with WithTracer is notified of progress.
+func GenerateKeyPairContext(ctx context.Context, rand io.Reader) (publicKey, privateKey []byte, err error) {
+	return DefaultGroup.GenerateKeyPairContext(ctx, rand)
+}
+
+// GenerateKeyPairWithBlinding is like GenerateKeyPair, but reads the private
+// key from keyRand, such as a hardware RNG, and random bytes for blinding from
+// blindRand, such as a fast CSPRNG. Both must be set to CSPRNGs: the
+// timing-attack defense relies on blinding values being unpredictable. If
+// either is nil, crypto/rand.Reader is used in its place.
+//
+// GeneratePublicKey and SharedKey read only blinding bytes from their rand
+// argument, so a separate blinding reader can be passed to them directly.
+func GenerateKeyPairWithBlinding(keyRand, blindRand io.Reader) (publicKey, privateKey []byte, err error) {
+	return DefaultGroup.GenerateKeyPairWithBlinding(keyRand, blindRand)
+}
+
+// GenerateKeyPairFromSeed deterministically derives a key pair from seed,
+// which must contain at least DefaultGroup.PrivateKeySize bytes of entropy.
+// For 32-byte private keys, such as Group14's, the private key is
+// SHA-256(seed). For other sizes it is the first DefaultGroup.PrivateKeySize
+// bytes of HKDF-SHA512 (RFC 5869) with seed as the input keying material, an
+// empty salt and the info string "dhgroup14 seed". The public key is
+// 2^(offset + privateKey) mod modulus, where privateKey is interpreted as a
+// big-endian integer, computed by GeneratePublicKeyDeterministic.
+func GenerateKeyPairFromSeed(seed []byte) (publicKey, privateKey []byte, err error) {
+	if size := DefaultGroup.PrivateKeySize; size != sha256.Size {
+		privateKey, err = hkdf.Key(sha512.New, seed, nil, "dhgroup14 seed", size)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		h := sha256.Sum256(seed)
+		defer Zeroize(h[:])
+		privateKey = append([]byte(nil), h[:]...)
 	}
-	publicKey, err = GeneratePublicKey(rand, privateKey)
+	publicKey, err = GeneratePublicKeyDeterministic(privateKey)
 	if err != nil {
+		Zeroize(privateKey)
 		return nil, nil, err
 	}
 	return
 }
 
 // GeneratePublicKey returns a public key corresponding to the given private
-// key (2^(2^258 + privateKey in group).
+// key (2^(2^258 + privateKey in group). The public key is always
+// DefaultGroup.PublicKeySize bytes long, left-padded with zero bytes.
 //
 // Random bytes for blinding are read from rand, which must be set to a CSPRNG,
-// such as crypto/rand.Reader.
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
 func GeneratePublicKey(rand io.Reader, privateKey []byte) (publicKey []byte, err error) {
-	if len(privateKey) != PrivateKeySize {
-		return nil, errors.New("dhgroup14: wrong private key size")
-	}
-	// Create public key: compute 2^(2^258 + privateKey)
-	return blindedModExp(rand, generator, privateKey)
+	return DefaultGroup.GeneratePublicKey(rand, privateKey)
 }
 
-func blindedModExp(rand io.Reader, a *big.Int, privateKey []byte) ([]byte, error) {
-	// Calculate 2^258 + privateKey
-	priv := new(big.Int).SetBytes(privateKey)
-	priv.Add(priv, twoExp256)
-	priv.Add(priv, twoExp256)
-	priv.Add(priv, twoExp256)
-	priv.Add(priv, twoExp256)
+// IsValidPrivateKey reports whether privateKey would be accepted by
+// GeneratePublicKey and SharedKey: it must be PrivateKeySize bytes and not
+// all zeros or all ones. Use it to check keys loaded from configuration.
+func IsValidPrivateKey(privateKey []byte) bool {
+	return DefaultGroup.IsValidPrivateKey(privateKey)
+}
 
-	// Generate random blinding exponent.
-	var blindingBytes [PrivateKeySize]byte
-	if _, err := io.ReadFull(rand, blindingBytes[:]); err != nil {
-		return nil, err
+// GeneratePublicKeyPadded is like GeneratePublicKey, but accepts private
+// keys shorter than DefaultGroup.PrivateKeySize, such as keys with leading
+// zero bytes stripped. The private key is interpreted as a big-endian
+// integer and left-padded with zeros to DefaultGroup.PrivateKeySize bytes.
+// Longer keys are rejected.
+func GeneratePublicKeyPadded(rand io.Reader, privateKey []byte) (publicKey []byte, err error) {
+	size := DefaultGroup.PrivateKeySize
+	if len(privateKey) > size {
+		return nil, ErrWrongPrivateKeySize
 	}
-	blinding := new(big.Int).SetBytes(blindingBytes[:])
-	blinding.Add(blinding, twoExp256)
+	padded := make([]byte, size)
+	defer Zeroize(padded)
+	copy(padded[size-len(privateKey):], privateKey)
+	return GeneratePublicKey(rand, padded)
+}
 
-	// Calculate blinded exponent.
-	privBlinded := priv.Sub(priv, blinding)
+// GeneratePublicKeyDeterministic returns the same public key as
+// GeneratePublicKey, but performs exponentiation without blinding, so it
+// does not need randomness.
+//
+// WARNING: this function is not resistant to timing attacks. It is intended
+// only for verification and test vector generation. Use GeneratePublicKey
+// for everything else.
+func GeneratePublicKeyDeterministic(privateKey []byte) (publicKey []byte, err error) {
+	return DefaultGroup.GeneratePublicKeyDeterministic(privateKey)
+}
 
-	// Exponentiate mod modulus.
-	r1 := new(big.Int).Exp(a, blinding, modulus)
-	r2 := new(big.Int).Exp(a, privBlinded, modulus)
+// VerifyKeyPair reports whether publicKey corresponds to privateKey, for
+// example to detect a corrupted or mismatched key pair loaded from storage.
+// It does not need randomness. See Group.VerifyKeyPair.
+func VerifyKeyPair(publicKey, privateKey []byte) (bool, error) {
+	return DefaultGroup.VerifyKeyPair(publicKey, privateKey)
+}
 
-	// Calculate result: (r1 * r2) mod modulus.
-	r1.Mul(r1, r2)
-	r1.Mod(r1, modulus)
+// SharedKey returns a shared key between theirPublicKey and myPrivateKey
+// (theirPublicKey^(2^258 + myPrivateKey).
+//
+// The shared key is always DefaultGroup.PublicKeySize bytes long: values
+// that are shorter are left-padded with zero bytes, as in spiped. Callers
+// must not strip leading zeros before hashing or comparing shared keys.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func SharedKey(rand io.Reader, theirPublicKey, myPrivateKey []byte) (sharedKey []byte, err error) {
+	return DefaultGroup.SharedKey(rand, theirPublicKey, myPrivateKey)
+}
 
-	if r1.BitLen() > modulus.BitLen() {
-		return nil, errors.New("dhgroup14: result is too large")
+// GeneratePublicKeyArray is like GeneratePublicKey, but returns the public
+// key as an array.
+func GeneratePublicKeyArray(rand io.Reader, privateKey []byte) (publicKey [PublicKeySize]byte, err error) {
+	b, err := Group14.GeneratePublicKey(rand, privateKey)
+	if err != nil {
+		return publicKey, err
 	}
+	copy(publicKey[:], b)
+	return publicKey, nil
+}
 
-	result := make([]byte, PublicKeySize)
-	rb := r1.Bytes()
-	copy(result[len(result)-len(rb):], rb)
+// SharedKeyArray is like SharedKey, but returns the shared key as an array.
+func SharedKeyArray(rand io.Reader, theirPublicKey, myPrivateKey []byte) (sharedKey [SharedKeySize]byte, err error) {
+	b, err := Group14.SharedKey(rand, theirPublicKey, myPrivateKey)
+	if err != nil {
+		return sharedKey, err
+	}
+	copy(sharedKey[:], b)
+	Zeroize(b)
+	return sharedKey, nil
+}
 
-	return result, nil
+// SharedKeyContext is like SharedKey, but returns ctx.Err() if ctx is done
+// before or during exponentiation.
+// A Tracer attached to ctx with WithTracer is notified of progress.
+func SharedKeyContext(ctx context.Context, rand io.Reader, theirPublicKey, myPrivateKey []byte) (sharedKey []byte, err error) {
+	return DefaultGroup.SharedKeyContext(ctx, rand, theirPublicKey, myPrivateKey)
 }
 
-// SharedKey returns a shared key between theirPublicKey and myPrivateKey
-// (theirPublicKey^(2^258 + myPrivateKey).
+// SharedKeyInto is like SharedKey, but writes the shared key into dst instead
+// of allocating it, so that servers can reuse buffers. dst must be
+// DefaultGroup.PublicKeySize bytes long, otherwise SharedKeyInto returns
+// ErrWrongSharedKeySize. The shared key is left-padded with zero bytes, as
+// with SharedKey. dst is only written if SharedKeyInto succeeds.
 //
 // Random bytes for blinding are read from rand, which must be set to a CSPRNG,
-// such as crypto/rand.Reader.
-func SharedKey(rand io.Reader, theirPublicKey, myPrivateKey []byte) (sharedKey []byte, err error) {
-	if len(theirPublicKey) != PublicKeySize {
-		return nil, errors.New("dhgroup14: wrong public key size")
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func SharedKeyInto(dst []byte, rand io.Reader, theirPublicKey, myPrivateKey []byte) error {
+	return DefaultGroup.SharedKeyInto(dst, rand, theirPublicKey, myPrivateKey)
+}
+
+// SharedKeyFromPrivates returns the shared key between two parties holding
+// privateKeyA and privateKeyB, by computing A's public key and then B's shared
+// key with it. It is intended for test oracles and demos that simulate both
+// parties in one process; real parties only ever hold one private key. The
+// result is the same with privateKeyA and privateKeyB swapped.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func SharedKeyFromPrivates(rand io.Reader, privateKeyA, privateKeyB []byte) (sharedKey []byte, err error) {
+	rand = randReader(rand)
+	publicKeyA, err := GeneratePublicKey(rand, privateKeyA)
+	if err != nil {
+		return nil, err
 	}
-	if len(myPrivateKey) != PrivateKeySize {
-		return nil, errors.New("dhgroup14: wrong private key size")
+	return SharedKey(rand, publicKeyA, privateKeyB)
+}
+
+// SharedKeyUnblinded returns the same shared key as SharedKey, but performs
+// exponentiation without blinding, so it does not need randomness.
+//
+// WARNING: this function is not resistant to timing attacks. It is intended
+// only for benchmarks and test vector cross-checking. Use SharedKey for
+// everything else.
+func SharedKeyUnblinded(theirPublicKey, myPrivateKey []byte) (sharedKey []byte, err error) {
+	return DefaultGroup.SharedKeyUnblinded(theirPublicKey, myPrivateKey)
+}
+
+// ValidatePublicKey checks that publicKey is a valid public key: it must be
+// greater than 1, less than modulus-1, at least 1024 bits long (half of the
+// modulus), and be an element of the prime-order subgroup generated by the
+// generator, that is publicKey^q = 1 mod modulus, where q = (modulus-1)/2.
+//
+// Values not less than the modulus are rejected with ErrPublicKeyOutOfRange.
+// modulus-1 is rejected with ErrDegeneratePublicKey; it is not in the
+// subgroup either, since it has order 2.
+//
+// Before the exponentiation, quadratic non-residues are rejected with
+// ErrPublicKeyNotInSubgroup by computing the Jacobi symbol, which is much
+// cheaper. For a safe prime modulus, the prime-order subgroup is exactly the
+// set of quadratic residues, so this already rejects every key outside of
+// it, including all keys whose exponentiation leaks the lowest bit of the
+// private key. It is still a partial check, because it only characterizes
+// the subgroup if the modulus is a safe prime, so the exponentiation is kept.
+//
+// SharedKey performs this validation on theirPublicKey. Validation is not
+// constant-time, which is safe because public keys are not secret.
+func ValidatePublicKey(publicKey []byte) error {
+	return DefaultGroup.ValidatePublicKey(publicKey)
+}
+
+// ValidatePublicKeyFast performs only the cheap checks of
+// ValidatePublicKey: publicKey must be DefaultGroup.PublicKeySize bytes,
+// greater than 1, less than modulus-1, at least 1024 bits long, and a
+// quadratic residue. It does not perform the subgroup exponentiation, which
+// for the safe prime moduli of this package is implied by the residue check
+// but not for arbitrary moduli. It is intended for tiering validation by trust level;
+// SharedKey always performs full validation.
+func ValidatePublicKeyFast(publicKey []byte) error {
+	return DefaultGroup.ValidatePublicKeyFast(publicKey)
+}
+
+// ValidatePublicKeySubgroup performs only the expensive check of
+// ValidatePublicKey: publicKey must be DefaultGroup.PublicKeySize bytes,
+// less than the modulus, and satisfy publicKey^q = 1 mod modulus, where
+// q = (modulus-1)/2. It does not reject 1, which is in the subgroup. Together,
+// ValidatePublicKeyFast and ValidatePublicKeySubgroup are equivalent to
+// ValidatePublicKey.
+func ValidatePublicKeySubgroup(publicKey []byte) error {
+	return DefaultGroup.ValidatePublicKeySubgroup(publicKey)
+}
+
+// ValidatePublicKeyStrict is like ValidatePublicKey, but additionally
+// rejects public keys equal to generator^k for 0 <= k < 8*PublicKeySize,
+// that is the powers of two 2^k for k < 2048, with ErrSmallPowerPublicKey.
+// Powers with k < 1023 are already rejected by ValidatePublicKey as too
+// small. An honestly generated public key is in this set with negligible
+// probability.
+//
+// Strict validation is slower than ValidatePublicKey and is intended for
+// high-assurance use; SharedKey does not perform it.
+func ValidatePublicKeyStrict(publicKey []byte) error {
+	return DefaultGroup.ValidatePublicKeyStrict(publicKey)
+}
+
+// IsIdentity reports whether publicKey, interpreted as a big-endian integer
+// of any length, equals 1, the identity element of the group.
+func IsIdentity(publicKey []byte) bool {
+	return new(big.Int).SetBytes(publicKey).Cmp(one) == 0
+}
+
+// Equal reports whether a and b, such as shared keys or key confirmation
+// tags, are equal. The time taken depends on the lengths of a and b, but not
+// on their contents: if the lengths differ, Equal returns false without
+// comparing any bytes, otherwise it compares all bytes, not stopping at the
+// first difference.
+func Equal(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// ClonePrivateKey returns a copy of privateKey that does not share memory
+// with it, so that either can be zeroized independently. It returns nil if
+// privateKey is nil.
+func ClonePrivateKey(privateKey []byte) []byte {
+	if privateKey == nil {
+		return nil
 	}
-	bp := new(big.Int).SetBytes(theirPublicKey)
-	// Check that public key is less than group modulus.
-	if bp.Cmp(modulus) > -1 {
-		return nil, errors.New("dhgroup14: public key is too large")
+	return append([]byte{}, privateKey...)
+}
+
+// ConstantTimeSelect returns a copy of a if condition is 1, or a copy of b if
+// condition is 0, such as to select between two shared keys without
+// branching on a secret. condition must be 0 or 1; any other value gives an
+// unspecified result. The time taken depends only on the lengths of a and b,
+// which must be equal; ConstantTimeSelect panics otherwise.
+func ConstantTimeSelect(condition int, a, b []byte) []byte {
+	if len(a) != len(b) {
+		panic("dhgroup14: ConstantTimeSelect: slices have different lengths")
 	}
-	// Calculate shared key.
-	return blindedModExp(rand, bp, myPrivateKey)
+	r := append([]byte{}, b...)
+	subtle.ConstantTimeCopy(condition, r, a)
+	return r
+}
+
+// Zeroize overwrites b, such as a private key that is no longer needed, with
+// zeros.
+func Zeroize(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// wipeInt overwrites the internal storage of x with zeros and sets x to 0.
+func wipeInt(x *big.Int) {
+	words := x.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	x.SetInt64(0)
+}
+
+// intPool holds scratch values for secret intermediate results.
+var intPool = sync.Pool{
+	New: func() interface{} { return new(big.Int) },
+}
+
+// getInt returns a zero value from intPool.
+func getInt() *big.Int {
+	return intPool.Get().(*big.Int)
+}
+
+// putInt wipes x and returns it to intPool.
+func putInt(x *big.Int) {
+	wipeInt(x)
+	intPool.Put(x)
 }
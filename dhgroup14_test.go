@@ -9,8 +9,20 @@ package dhgroup14
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+	mathrand "math/rand"
+	randv2 "math/rand/v2"
+	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
+	"testing/quick"
 )
 
 // Test vectors generated by Colin Percival's implementation
@@ -120,6 +132,44 @@ var golden = struct {
 	},
 }
 
+func TestGroupAccessors(t *testing.T) {
+	m := Modulus()
+	if m.Cmp(modulus) != 0 {
+		t.Fatalf("wrong modulus")
+	}
+	m.SetInt64(1)
+	if modulus.BitLen() != 2048 {
+		t.Fatalf("modulus was mutated")
+	}
+	b := ModulusBytes()
+	if len(b) != PublicKeySize || new(big.Int).SetBytes(b).Cmp(modulus) != 0 {
+		t.Fatalf("wrong modulus bytes")
+	}
+	b[0] = 0
+	if modulus.BitLen() != 2048 {
+		t.Fatalf("modulus was mutated")
+	}
+	g := Generator()
+	if g.Cmp(big.NewInt(2)) != 0 {
+		t.Fatalf("wrong generator: %s", g)
+	}
+	g.SetInt64(5)
+	if generator.Cmp(big.NewInt(2)) != 0 {
+		t.Fatalf("generator was mutated")
+	}
+	q := SubgroupOrder()
+	if p := new(big.Int).Add(new(big.Int).Lsh(q, 1), one); p.Cmp(modulus) != 0 {
+		t.Fatalf("2*q + 1 != modulus")
+	}
+	if !q.ProbablyPrime(20) {
+		t.Fatalf("subgroup order is not prime")
+	}
+	q.SetInt64(1)
+	if SubgroupOrder().Cmp(new(big.Int).Rsh(modulus, 1)) != 0 {
+		t.Fatalf("subgroup order was mutated")
+	}
+}
+
 func TestGenerateKey(t *testing.T) {
 	publicKey1, privateKey1, err := GenerateKeyPair(rand.Reader)
 	if err != nil {
@@ -148,6 +198,232 @@ func TestGenerateKey(t *testing.T) {
 	}
 }
 
+func TestNilRand(t *testing.T) {
+	publicKey1, privateKey1, err := GenerateKeyPair(nil)
+	if err != nil {
+		t.Fatalf("generate key pair: %s", err)
+	}
+	publicKey, err := GeneratePublicKey(nil, privateKey1)
+	if err != nil {
+		t.Fatalf("generate public key: %s", err)
+	}
+	if !bytes.Equal(publicKey, publicKey1) {
+		t.Fatalf("generated wrong public key")
+	}
+	sharedKey, err := SharedKey(nil, golden.publicKey1, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("compute shared key: %s", err)
+	}
+	if !bytes.Equal(sharedKey, golden.sharedKey) {
+		t.Fatalf(`expecting "%x", got "%x"`, golden.sharedKey, sharedKey)
+	}
+	typedPublicKey, typedPrivateKey, err := GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %s", err)
+	}
+	publicKey, err = GeneratePublicKey(nil, typedPrivateKey[:])
+	if err != nil {
+		t.Fatalf("generate public key: %s", err)
+	}
+	if !bytes.Equal(publicKey, typedPublicKey[:]) {
+		t.Fatalf("GenerateKey generated wrong public key")
+	}
+}
+
+func TestGenerateKeyPairFromSeed(t *testing.T) {
+	seed := []byte("0123456789abcdef0123456789abcdef")
+	publicKey1, privateKey1, err := GenerateKeyPairFromSeed(seed)
+	if err != nil {
+		t.Fatalf("generate key pair 1: %s", err)
+	}
+	publicKey2, privateKey2, err := GenerateKeyPairFromSeed(seed)
+	if err != nil {
+		t.Fatalf("generate key pair 2: %s", err)
+	}
+	if !bytes.Equal(privateKey1, privateKey2) || !bytes.Equal(publicKey1, publicKey2) {
+		t.Fatalf("key pairs from the same seed differ")
+	}
+	if h := sha256.Sum256(seed); !bytes.Equal(privateKey1, h[:]) {
+		t.Fatalf("private key is not SHA-256 of seed")
+	}
+	publicKey, err := GeneratePublicKey(rand.Reader, privateKey1)
+	if err != nil {
+		t.Fatalf("generate public key: %s", err)
+	}
+	if !bytes.Equal(publicKey, publicKey1) {
+		t.Fatalf("public key doesn't match private key")
+	}
+	_, privateKey3, err := GenerateKeyPairFromSeed(seed[1:])
+	if err != nil {
+		t.Fatalf("generate key pair 3: %s", err)
+	}
+	if bytes.Equal(privateKey1, privateKey3) {
+		t.Fatalf("key pairs from different seeds are equal")
+	}
+}
+
+// countingReader counts bytes read from crypto/rand.Reader.
+type countingReader struct {
+	n int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := rand.Read(p)
+	r.n += n
+	return n, err
+}
+
+func TestRandomnessConsumption(t *testing.T) {
+	r := new(countingReader)
+	if _, _, err := GenerateKeyPair(r); err != nil {
+		t.Fatal(err)
+	}
+	if r.n != 2*PrivateKeySize {
+		t.Errorf("GenerateKeyPair read %d bytes, expected %d", r.n, 2*PrivateKeySize)
+	}
+	r.n = 0
+	if _, err := GeneratePublicKey(r, golden.privateKey1); err != nil {
+		t.Fatal(err)
+	}
+	if r.n != PrivateKeySize {
+		t.Errorf("GeneratePublicKey read %d bytes, expected %d", r.n, PrivateKeySize)
+	}
+	r.n = 0
+	if _, err := SharedKey(r, golden.publicKey1, golden.privateKey2); err != nil {
+		t.Fatal(err)
+	}
+	if r.n != PrivateKeySize {
+		t.Errorf("SharedKey read %d bytes, expected %d", r.n, PrivateKeySize)
+	}
+}
+
+func TestRandomnessConsumptionGroup18(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow group #18 test in short mode")
+	}
+	defer func(g *Group) { DefaultGroup = g }(DefaultGroup)
+	DefaultGroup = Group18
+
+	r := new(countingReader)
+	publicKey, privateKey, err := GenerateKeyPair(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.n != 2*Group18.PrivateKeySize {
+		t.Errorf("GenerateKeyPair read %d bytes, expected %d", r.n, 2*Group18.PrivateKeySize)
+	}
+	r.n = 0
+	if _, err := SharedKey(r, publicKey, privateKey); err != nil {
+		t.Fatal(err)
+	}
+	if r.n != Group18.PrivateKeySize {
+		t.Errorf("SharedKey read %d bytes, expected %d", r.n, Group18.PrivateKeySize)
+	}
+}
+
+func TestPartialReads(t *testing.T) {
+	data := make([]byte, 2*PrivateKeySize+1)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	readers := []struct {
+		name string
+		wrap func(io.Reader) io.Reader
+	}{
+		{"one byte", iotest.OneByteReader},
+		{"half", iotest.HalfReader},
+	}
+	for _, v := range readers {
+		src := bytes.NewReader(data)
+		publicKey, privateKey, err := GenerateKeyPair(v.wrap(src))
+		if err != nil {
+			t.Fatalf("%s: GenerateKeyPair: %s", v.name, err)
+		}
+		if !bytes.Equal(privateKey, data[:PrivateKeySize]) {
+			t.Fatalf("%s: private key is not the first bytes read", v.name)
+		}
+		if src.Len() != 1 {
+			t.Fatalf("%s: read %d bytes, expected %d", v.name, len(data)-src.Len(), 2*PrivateKeySize)
+		}
+		expected, err := GeneratePublicKeyDeterministic(privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(publicKey, expected) {
+			t.Fatalf("%s: wrong public key", v.name)
+		}
+		src = bytes.NewReader(data)
+		if _, err := SharedKey(v.wrap(src), golden.publicKey1, golden.privateKey2); err != nil {
+			t.Fatalf("%s: SharedKey: %s", v.name, err)
+		}
+		if src.Len() != len(data)-PrivateKeySize {
+			t.Fatalf("%s: SharedKey read %d bytes, expected %d", v.name, len(data)-src.Len(), PrivateKeySize)
+		}
+	}
+}
+
+func TestGenerateKeyPairWithBlinding(t *testing.T) {
+	keyRand, blindRand := new(countingReader), new(countingReader)
+	publicKey, privateKey, err := GenerateKeyPairWithBlinding(keyRand, blindRand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyRand.n != PrivateKeySize || blindRand.n != PrivateKeySize {
+		t.Errorf("read %d key bytes and %d blinding bytes, expected %d each", keyRand.n, blindRand.n, PrivateKeySize)
+	}
+	expected, err := GeneratePublicKey(rand.Reader, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(publicKey, expected) {
+		t.Fatalf("wrong public key")
+	}
+	if _, _, err := GenerateKeyPairWithBlinding(rand.Reader, errorReader{}); !errors.Is(err, errRead) {
+		t.Errorf("blinding read: expected %v, got %v", errRead, err)
+	}
+	if _, _, err := GenerateKeyPairWithBlinding(errorReader{}, rand.Reader); !errors.Is(err, errRead) {
+		t.Errorf("key read: expected %v, got %v", errRead, err)
+	}
+}
+
+func TestRandomnessReadErrors(t *testing.T) {
+	// Fail while reading private key.
+	r := io.MultiReader(io.LimitReader(rand.Reader, PrivateKeySize-1), errorReader{})
+	_, _, err := GenerateKeyPair(r)
+	if !errors.Is(err, errRead) || !errors.Is(err, ErrRandomnessFailed) || !strings.Contains(err.Error(), "reading private key") {
+		t.Errorf("GenerateKeyPair: unexpected error %v", err)
+	}
+	// Fail while reading blinding.
+	r = io.MultiReader(io.LimitReader(rand.Reader, PrivateKeySize+1), errorReader{})
+	_, _, err = GenerateKeyPair(r)
+	if !errors.Is(err, errRead) || !errors.Is(err, ErrRandomnessFailed) || !strings.Contains(err.Error(), "reading blinding") {
+		t.Errorf("GenerateKeyPair: unexpected error %v", err)
+	}
+	_, err = SharedKey(errorReader{}, golden.publicKey1, golden.privateKey2)
+	if !errors.Is(err, errRead) || !errors.Is(err, ErrRandomnessFailed) || !strings.Contains(err.Error(), "reading blinding") {
+		t.Errorf("SharedKey: unexpected error %v", err)
+	}
+	// Broken source returning only zeros.
+	_, _, err = GenerateKeyPair(bytes.NewReader(make([]byte, 2*PrivateKeySize)))
+	if !errors.Is(err, ErrWeakPrivateKey) || !errors.Is(err, ErrRandomnessFailed) {
+		t.Errorf("GenerateKeyPair: zero source: unexpected error %v", err)
+	}
+	// Short read.
+	_, err = GeneratePublicKey(io.LimitReader(rand.Reader, PrivateKeySize-1), golden.privateKey1)
+	if !errors.Is(err, io.ErrUnexpectedEOF) || !errors.Is(err, ErrRandomnessFailed) {
+		t.Errorf("GeneratePublicKey: unexpected error %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "dhgroup14: randomness source failed: ") {
+		t.Errorf("GeneratePublicKey: unexpected message %q", err)
+	}
+}
+
+func TestRandomnessAvailable(t *testing.T) {
+	if err := RandomnessAvailable(); err != nil {
+		t.Fatalf("crypto/rand is not available: %s", err)
+	}
+}
+
 func TestGeneratePublicKey(t *testing.T) {
 	publicKey, err := GeneratePublicKey(rand.Reader, golden.privateKey1)
 	if err != nil {
@@ -158,6 +434,183 @@ func TestGeneratePublicKey(t *testing.T) {
 	}
 }
 
+func TestGenerateKeyPairFromSeedGroup18(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow group #18 test in short mode")
+	}
+	defer func(g *Group) { DefaultGroup = g }(DefaultGroup)
+	DefaultGroup = Group18
+
+	seed := []byte("0123456789abcdef0123456789abcdef0123456789abcdef")
+	publicKey, privateKey, err := GenerateKeyPairFromSeed(seed)
+	if err != nil {
+		t.Fatalf("generate key pair: %s", err)
+	}
+	if len(privateKey) != 48 || len(publicKey) != 1024 {
+		t.Fatalf("wrong key sizes: %d-byte private key, %d-byte public key", len(privateKey), len(publicKey))
+	}
+	if ok, err := VerifyKeyPair(publicKey, privateKey); err != nil || !ok {
+		t.Fatalf("key pair doesn't verify: %t, %v", ok, err)
+	}
+	_, privateKey2, err := GenerateKeyPairFromSeed(seed)
+	if err != nil {
+		t.Fatalf("generate key pair 2: %s", err)
+	}
+	if !bytes.Equal(privateKey, privateKey2) {
+		t.Fatalf("key pairs from the same seed differ")
+	}
+	// Known answer computed independently from RFC 5869 and RFC 3526.
+	const (
+		expectedPrivateKey    = "d0220a261570bf8c70ed20a3cb23b779b23dba755f03a4fde8ac09860eaa65b1c43edab6782c2529ae4163c60824b6a1"
+		expectedPublicKeyHash = "3787efb1def0990ab0ee4cdc0fbe5660c3d16fd23f0f69835c661c1866f55522"
+	)
+	if got := hex.EncodeToString(privateKey); got != expectedPrivateKey {
+		t.Fatalf("private key: expected %s, got %s", expectedPrivateKey, got)
+	}
+	h := sha256.Sum256(publicKey)
+	if got := hex.EncodeToString(h[:]); got != expectedPublicKeyHash {
+		t.Fatalf("public key hash: expected %s, got %s", expectedPublicKeyHash, got)
+	}
+}
+
+func TestGeneratePublicKeyPadded(t *testing.T) {
+	publicKey, err := GeneratePublicKeyPadded(rand.Reader, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("full-size key: %s", err)
+	}
+	if !bytes.Equal(publicKey, golden.publicKey1) {
+		t.Fatalf("full-size key: wrong public key")
+	}
+	privateKey := make([]byte, PrivateKeySize)
+	copy(privateKey[2:], golden.privateKey1[2:])
+	expected, err := GeneratePublicKey(rand.Reader, privateKey)
+	if err != nil {
+		t.Fatalf("padded key: %s", err)
+	}
+	publicKey, err = GeneratePublicKeyPadded(rand.Reader, privateKey[2:])
+	if err != nil {
+		t.Fatalf("short key: %s", err)
+	}
+	if !bytes.Equal(publicKey, expected) {
+		t.Fatalf("short key: wrong public key")
+	}
+	if _, err := GeneratePublicKeyPadded(rand.Reader, append(golden.privateKey1, 1)); err != ErrWrongPrivateKeySize {
+		t.Fatalf("long key: expected %v, got %v", ErrWrongPrivateKeySize, err)
+	}
+	if _, err := GeneratePublicKeyPadded(rand.Reader, nil); err != ErrWeakPrivateKey {
+		t.Fatalf("empty key: expected %v, got %v", ErrWeakPrivateKey, err)
+	}
+}
+
+func TestGeneratePublicKeyPaddedGroup18(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow group #18 test in short mode")
+	}
+	defer func(g *Group) { DefaultGroup = g }(DefaultGroup)
+	DefaultGroup = Group18
+
+	privateKey := make([]byte, 48)
+	copy(privateKey[2:], golden.privateKey1)
+	expected, err := GeneratePublicKeyDeterministic(privateKey)
+	if err != nil {
+		t.Fatalf("padded key: %s", err)
+	}
+	publicKey, err := GeneratePublicKeyPadded(rand.Reader, privateKey[2:])
+	if err != nil {
+		t.Fatalf("short key: %s", err)
+	}
+	if !bytes.Equal(publicKey, expected) {
+		t.Fatalf("short key: wrong public key")
+	}
+	if _, err := GeneratePublicKeyPadded(rand.Reader, append(privateKey, 1)); err != ErrWrongPrivateKeySize {
+		t.Fatalf("long key: expected %v, got %v", ErrWrongPrivateKeySize, err)
+	}
+}
+
+func TestGeneratePublicKeyDeterministic(t *testing.T) {
+	for i, privateKey := range [][]byte{golden.privateKey1, golden.privateKey2} {
+		publicKey, err := GeneratePublicKeyDeterministic(privateKey)
+		if err != nil {
+			t.Fatalf("%d: deterministic public key: %s", i, err)
+		}
+		blinded, err := GeneratePublicKey(rand.Reader, privateKey)
+		if err != nil {
+			t.Fatalf("%d: blinded public key: %s", i, err)
+		}
+		if !bytes.Equal(publicKey, blinded) {
+			t.Fatalf("%d: deterministic and blinded public keys differ: %x, %x", i, publicKey, blinded)
+		}
+	}
+	if _, err := GeneratePublicKeyDeterministic(golden.privateKey1[1:]); err == nil {
+		t.Fatalf("accepted short private key")
+	}
+}
+
+func TestVerifyKeyPair(t *testing.T) {
+	ok, err := VerifyKeyPair(golden.publicKey1, golden.privateKey1)
+	if err != nil || !ok {
+		t.Fatalf("matching pair: got %v, %v", ok, err)
+	}
+	ok, err = VerifyKeyPair(golden.publicKey2, golden.privateKey1)
+	if err != nil || ok {
+		t.Fatalf("mismatched pair: got %v, %v", ok, err)
+	}
+	corrupted := append([]byte(nil), golden.publicKey1...)
+	corrupted[PublicKeySize-1] ^= 1
+	ok, err = VerifyKeyPair(corrupted, golden.privateKey1)
+	if err != nil || ok {
+		t.Fatalf("corrupted public key: got %v, %v", ok, err)
+	}
+	if _, err := VerifyKeyPair(golden.publicKey1[1:], golden.privateKey1); err != ErrWrongPublicKeySize {
+		t.Fatalf("short public key: expected %v, got %v", ErrWrongPublicKeySize, err)
+	}
+	if _, err := VerifyKeyPair(golden.publicKey1, golden.privateKey1[1:]); err != ErrWrongPrivateKeySize {
+		t.Fatalf("short private key: expected %v, got %v", ErrWrongPrivateKeySize, err)
+	}
+}
+
+func TestWeakPrivateKey(t *testing.T) {
+	zeros := make([]byte, PrivateKeySize)
+	ones := bytes.Repeat([]byte{0xff}, PrivateKeySize)
+	for _, privateKey := range [][]byte{zeros, ones} {
+		if _, err := GeneratePublicKey(rand.Reader, privateKey); !errors.Is(err, ErrWeakPrivateKey) {
+			t.Errorf("GeneratePublicKey: expected %v, got %v", ErrWeakPrivateKey, err)
+		}
+		if _, err := GeneratePublicKeyDeterministic(privateKey); err == nil {
+			t.Errorf("GeneratePublicKeyDeterministic accepted private key %x", privateKey)
+		}
+		if _, err := SharedKey(rand.Reader, golden.publicKey1, privateKey); err == nil {
+			t.Errorf("SharedKey accepted private key %x", privateKey)
+		}
+	}
+	if _, err := GeneratePublicKey(rand.Reader, golden.privateKey1); err != nil {
+		t.Errorf("GeneratePublicKey rejected valid private key: %s", err)
+	}
+}
+
+func TestIsValidPrivateKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		privateKey []byte
+	}{
+		{"normal", golden.privateKey1},
+		{"zeros", make([]byte, PrivateKeySize)},
+		{"ones", bytes.Repeat([]byte{0xff}, PrivateKeySize)},
+		{"short", golden.privateKey1[1:]},
+		{"long", append(append([]byte(nil), golden.privateKey1...), 1)},
+		{"empty", nil},
+	}
+	for _, v := range tests {
+		_, err := GeneratePublicKey(rand.Reader, v.privateKey)
+		if valid := IsValidPrivateKey(v.privateKey); valid != (err == nil) {
+			t.Errorf("%s: IsValidPrivateKey returned %v, GeneratePublicKey returned %v", v.name, valid, err)
+		}
+	}
+	if !IsValidPrivateKey(golden.privateKey1) {
+		t.Errorf("normal key rejected")
+	}
+}
+
 func TestSharedKey(t *testing.T) {
 	sharedKey1, err := SharedKey(rand.Reader, golden.publicKey1, golden.privateKey2)
 	if err != nil {
@@ -175,6 +628,636 @@ func TestSharedKey(t *testing.T) {
 	}
 }
 
+// TestConcurrentSharedKey runs key generation and shared key computations
+// from many goroutines, which share modulus, generator and the precomputed
+// tables. Run it with -race to check that none of them are written to.
+func TestConcurrentSharedKey(t *testing.T) {
+	defer func(p bool) { parallelExp = p }(parallelExp)
+	parallelExp = true
+	savedModulus := new(big.Int).Set(modulus)
+	savedOffset := new(big.Int).Set(twoExp258)
+
+	const goroutines = 8
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sharedKey, err := SharedKey(rand.Reader, golden.publicKey1, golden.privateKey2)
+			if err != nil {
+				t.Errorf("shared key: %s", err)
+				return
+			}
+			if !bytes.Equal(sharedKey, golden.sharedKey) {
+				t.Errorf("shared key doesn't match")
+			}
+			if _, _, err := GenerateKeyPair(rand.Reader); err != nil {
+				t.Errorf("generate key pair: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if modulus.Cmp(savedModulus) != 0 || twoExp258.Cmp(savedOffset) != 0 || generator.Cmp(big.NewInt(2)) != 0 {
+		t.Fatalf("shared values were modified")
+	}
+	if err := SelfCheck(); err != nil {
+		t.Fatalf("self check: %s", err)
+	}
+}
+
+// TestSharedKeyAgreement checks the defining property of Diffie-Hellman:
+// both parties compute the same shared key. Private keys and blinding values
+// are generated from fixed seeds for reproducibility.
+func TestSharedKeyAgreement(t *testing.T) {
+	blindRand := randv2.NewChaCha8([32]byte{'d', 'h', 'g', 'r', 'o', 'u', 'p', '1', '4'})
+	agree := func(privateKey1, privateKey2 PrivateKey) bool {
+		publicKey1, err1 := GeneratePublicKey(blindRand, privateKey1[:])
+		publicKey2, err2 := GeneratePublicKey(blindRand, privateKey2[:])
+		if err1 != nil || err2 != nil {
+			// Weak private keys are not expected from the generator.
+			return false
+		}
+		sharedKey1, err1 := SharedKey(blindRand, publicKey2, privateKey1[:])
+		sharedKey2, err2 := SharedKey(blindRand, publicKey1, privateKey2[:])
+		return err1 == nil && err2 == nil && bytes.Equal(sharedKey1, sharedKey2)
+	}
+	config := &quick.Config{
+		MaxCount: 20,
+		Rand:     mathrand.New(mathrand.NewSource(14)),
+	}
+	if err := quick.Check(agree, config); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSharedKeyInto(t *testing.T) {
+	dst := make([]byte, SharedKeySize)
+	for i := range dst {
+		dst[i] = 0xaa
+	}
+	if err := SharedKeyInto(dst, rand.Reader, golden.publicKey1, golden.privateKey2); err != nil {
+		t.Fatalf("shared key: %s", err)
+	}
+	if !bytes.Equal(dst, golden.sharedKey) {
+		t.Fatalf("shared key doesn't match")
+	}
+	// The shared key of golden.publicKey1 and private key 206 has a leading
+	// zero byte, which must be written over the previous contents.
+	privateKey := make([]byte, PrivateKeySize)
+	privateKey[PrivateKeySize-1] = 206
+	expected, err := SharedKey(rand.Reader, golden.publicKey1, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SharedKeyInto(dst, rand.Reader, golden.publicKey1, privateKey); err != nil {
+		t.Fatal(err)
+	}
+	if dst[0] != 0 || !bytes.Equal(dst, expected) {
+		t.Fatalf("short shared key doesn't match SharedKey")
+	}
+
+	for _, n := range []int{0, SharedKeySize - 1, SharedKeySize + 1} {
+		if err := SharedKeyInto(make([]byte, n), rand.Reader, golden.publicKey1, golden.privateKey2); err != ErrWrongSharedKeySize {
+			t.Errorf("%d bytes: expected %v, got %v", n, ErrWrongSharedKeySize, err)
+		}
+	}
+	// dst is left unchanged on error.
+	copy(dst, golden.sharedKey)
+	if err := SharedKeyInto(dst, rand.Reader, publicKeyBytes(one), golden.privateKey2); err != ErrDegeneratePublicKey {
+		t.Fatalf("expected %v, got %v", ErrDegeneratePublicKey, err)
+	}
+	if !bytes.Equal(dst, golden.sharedKey) {
+		t.Fatalf("dst modified on error")
+	}
+}
+
+func TestSharedKeyFromPrivates(t *testing.T) {
+	sharedKey, err := SharedKeyFromPrivates(rand.Reader, golden.privateKey2, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("shared key: %s", err)
+	}
+	if !bytes.Equal(sharedKey, golden.sharedKey) {
+		t.Fatalf("shared key doesn't match")
+	}
+	_, privateKeyA, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, privateKeyB, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sharedKeyAB, err := SharedKeyFromPrivates(nil, privateKeyA, privateKeyB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sharedKeyBA, err := SharedKeyFromPrivates(nil, privateKeyB, privateKeyA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sharedKeyAB, sharedKeyBA) {
+		t.Fatalf("shared key is not symmetric")
+	}
+	if _, err := SharedKeyFromPrivates(rand.Reader, privateKeyA[1:], privateKeyB); err != ErrWrongPrivateKeySize {
+		t.Fatalf("expected %v, got %v", ErrWrongPrivateKeySize, err)
+	}
+}
+
+func TestSharedKeyUnblinded(t *testing.T) {
+	sharedKey, err := SharedKeyUnblinded(golden.publicKey1, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("unblinded shared key: %s", err)
+	}
+	blinded, err := SharedKey(rand.Reader, golden.publicKey1, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("blinded shared key: %s", err)
+	}
+	if !bytes.Equal(sharedKey, blinded) || !bytes.Equal(sharedKey, golden.sharedKey) {
+		t.Fatalf("unblinded and blinded shared keys differ: %x, %x", sharedKey, blinded)
+	}
+	if _, err := SharedKeyUnblinded(publicKeyBytes(one), golden.privateKey2); err != ErrDegeneratePublicKey {
+		t.Fatalf("degenerate public key: expected %v, got %v", ErrDegeneratePublicKey, err)
+	}
+	if _, err := SharedKeyUnblinded(golden.publicKey1, golden.privateKey2[1:]); err != ErrWrongPrivateKeySize {
+		t.Fatalf("short private key: expected %v, got %v", ErrWrongPrivateKeySize, err)
+	}
+}
+
+// shortPublicKeyPrivateKey is a private key whose public key has a leading
+// zero byte.
+var shortPublicKeyPrivateKey = []byte{
+	0xd7, 0x89, 0x1f, 0x47, 0x73, 0x99, 0xe5, 0x7a, 0xec, 0x0b,
+	0x98, 0x1c, 0xf3, 0x19, 0xc3, 0xd0, 0xd6, 0x5e, 0x03, 0x05,
+	0xad, 0x3d, 0x95, 0x3f, 0x03, 0xc6, 0x3a, 0xae, 0xcb, 0x65,
+	0xb8, 0xae,
+}
+
+func TestLeadingZeroPadding(t *testing.T) {
+	publicKey, err := GeneratePublicKey(rand.Reader, shortPublicKeyPrivateKey)
+	if err != nil {
+		t.Fatalf("generating public key: %s", err)
+	}
+	if len(publicKey) != PublicKeySize || publicKey[0] != 0 {
+		t.Fatalf("public key is not left-padded: length %d, first byte %#x", len(publicKey), publicKey[0])
+	}
+	if _, err := SharedKey(rand.Reader, publicKey, golden.privateKey1); err != nil {
+		t.Fatalf("rejected padded public key: %s", err)
+	}
+
+	// Shared key between golden.publicKey1 and this private key is
+	// shorter than SharedKeySize.
+	privateKey := []byte{
+		0x10, 0xaa, 0x40, 0x43, 0x1f, 0x6b, 0x29, 0x88, 0x46, 0x4d,
+		0x08, 0x00, 0xd6, 0x9e, 0xd0, 0x9e, 0x21, 0xfe, 0xdb, 0x6c,
+		0xe5, 0x8f, 0x59, 0x2a, 0x13, 0x43, 0x7a, 0x5e, 0xc3, 0x19,
+		0xa2, 0x0c,
+	}
+	sharedKey1, err := SharedKey(rand.Reader, golden.publicKey1, privateKey)
+	if err != nil {
+		t.Fatalf("compute shared key 1: %s", err)
+	}
+	if len(sharedKey1) != SharedKeySize || sharedKey1[0] != 0 {
+		t.Fatalf("shared key is not left-padded: length %d, first byte %#x", len(sharedKey1), sharedKey1[0])
+	}
+	publicKey, err = GeneratePublicKey(rand.Reader, privateKey)
+	if err != nil {
+		t.Fatalf("generating public key: %s", err)
+	}
+	sharedKey2, err := SharedKey(rand.Reader, publicKey, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("compute shared key 2: %s", err)
+	}
+	if !bytes.Equal(sharedKey1, sharedKey2) {
+		t.Fatalf("shared keys differ: %x, %x", sharedKey1, sharedKey2)
+	}
+}
+
+func TestEncodeResultPadding(t *testing.T) {
+	for _, x := range []int64{2, 0x100, 0xffff} {
+		r, err := Group14.encodeResult(big.NewInt(x))
+		if err != nil {
+			t.Fatalf("%d: %s", x, err)
+		}
+		if !bytes.Equal(r, publicKeyBytes(big.NewInt(x))) {
+			t.Fatalf("%d: wrong encoding %x", x, r)
+		}
+	}
+	if _, err := Group14.encodeResult(new(big.Int).Lsh(one, 8*PublicKeySize)); err != ErrResultTooLarge {
+		t.Fatalf("expected %v, got %v", ErrResultTooLarge, err)
+	}
+}
+
+// publicKeyBytes returns x encoded as a PublicKeySize-byte big-endian value.
+func publicKeyBytes(x *big.Int) []byte {
+	b := make([]byte, PublicKeySize)
+	xb := x.Bytes()
+	copy(b[len(b)-len(xb):], xb)
+	return b
+}
+
+func TestArrayResults(t *testing.T) {
+	publicKey, err := GeneratePublicKeyArray(rand.Reader, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("generate public key: %s", err)
+	}
+	if !bytes.Equal(publicKey[:], golden.publicKey1) {
+		t.Fatalf("generated wrong public key")
+	}
+	sharedKey, err := SharedKeyArray(rand.Reader, golden.publicKey1, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("compute shared key: %s", err)
+	}
+	if !bytes.Equal(sharedKey[:], golden.sharedKey) {
+		t.Fatalf(`expecting "%x", got "%x"`, golden.sharedKey, sharedKey)
+	}
+	if _, err := SharedKeyArray(rand.Reader, golden.publicKey1[1:], golden.privateKey2); err != ErrWrongPublicKeySize {
+		t.Fatalf("expected %v, got %v", ErrWrongPublicKeySize, err)
+	}
+}
+
+func TestContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := GenerateKeyPairContext(ctx, rand.Reader); err != context.Canceled {
+		t.Errorf("GenerateKeyPairContext: expected %v, got %v", context.Canceled, err)
+	}
+	if _, err := SharedKeyContext(ctx, rand.Reader, golden.publicKey1, golden.privateKey2); err != context.Canceled {
+		t.Errorf("SharedKeyContext: expected %v, got %v", context.Canceled, err)
+	}
+	sharedKey, err := SharedKeyContext(context.Background(), rand.Reader, golden.publicKey1, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("SharedKeyContext: %s", err)
+	}
+	if !bytes.Equal(sharedKey, golden.sharedKey) {
+		t.Fatalf(`expecting "%x", got "%x"`, golden.sharedKey, sharedKey)
+	}
+}
+
+func TestContextCanceledDuringExponentiation(t *testing.T) {
+	defer func(p bool) { parallelExp = p }(parallelExp)
+	base := new(big.Int).SetBytes(golden.publicKey1)
+	for _, parallel := range []bool{false, true} {
+		parallelExp = parallel
+		ctx, cancel := context.WithCancel(context.Background())
+		exp := func(z, x *big.Int) *big.Int {
+			cancel()
+			return Group14.baseExp(base)(z, x)
+		}
+		if _, err := Group14.blindedModExp(ctx, rand.Reader, exp, golden.privateKey2); err != context.Canceled {
+			t.Errorf("parallel %t: expected %v, got %v", parallel, context.Canceled, err)
+		}
+	}
+}
+
+func TestValidatePublicKey(t *testing.T) {
+	if err := ValidatePublicKey(golden.publicKey1); err != nil {
+		t.Fatalf("valid public key 1 rejected: %s", err)
+	}
+	if err := ValidatePublicKey(golden.publicKey2); err != nil {
+		t.Fatalf("valid public key 2 rejected: %s", err)
+	}
+	bad := []struct {
+		name      string
+		publicKey []byte
+		err       error
+	}{
+		{"short", golden.publicKey1[1:], ErrWrongPublicKeySize},
+		{"zero", publicKeyBytes(big.NewInt(0)), ErrDegeneratePublicKey},
+		{"one", publicKeyBytes(big.NewInt(1)), ErrDegeneratePublicKey},
+		{"modulus-1", publicKeyBytes(new(big.Int).Sub(modulus, big.NewInt(1))), ErrDegeneratePublicKey},
+		{"modulus", publicKeyBytes(modulus), ErrPublicKeyOutOfRange},
+		{"2", publicKeyBytes(big.NewInt(2)), ErrPublicKeyTooSmall},
+		{"2^1023-1", publicKeyBytes(new(big.Int).Sub(new(big.Int).Lsh(one, 1023), one)), ErrPublicKeyTooSmall},
+		{"non-residue", publicKeyBytes(new(big.Int).Sub(modulus, big.NewInt(2))), ErrPublicKeyNotInSubgroup},
+	}
+	for _, v := range bad {
+		if err := ValidatePublicKey(v.publicKey); !errors.Is(err, v.err) {
+			t.Errorf("%s: expected %v, got %v", v.name, v.err, err)
+		}
+		if _, err := SharedKey(rand.Reader, v.publicKey, golden.privateKey1); !errors.Is(err, v.err) {
+			t.Errorf("%s: SharedKey: expected %v, got %v", v.name, v.err, err)
+		}
+	}
+}
+
+func TestValidatePublicKeyTiers(t *testing.T) {
+	tests := []struct {
+		name      string
+		publicKey []byte
+		fast      error
+		subgroup  error
+	}{
+		{"valid", golden.publicKey1, nil, nil},
+		{"short", golden.publicKey1[1:], ErrWrongPublicKeySize, ErrWrongPublicKeySize},
+		{"zero", publicKeyBytes(big.NewInt(0)), ErrDegeneratePublicKey, ErrPublicKeyNotInSubgroup},
+		{"one", publicKeyBytes(big.NewInt(1)), ErrDegeneratePublicKey, nil},
+		{"modulus-1", publicKeyBytes(new(big.Int).Sub(modulus, big.NewInt(1))), ErrDegeneratePublicKey, ErrPublicKeyNotInSubgroup},
+		{"modulus", publicKeyBytes(modulus), ErrPublicKeyOutOfRange, ErrPublicKeyOutOfRange},
+		{"2", publicKeyBytes(big.NewInt(2)), ErrPublicKeyTooSmall, nil},
+		{"non-residue", publicKeyBytes(new(big.Int).Sub(modulus, big.NewInt(2))), ErrPublicKeyNotInSubgroup, ErrPublicKeyNotInSubgroup},
+	}
+	for _, v := range tests {
+		if err := ValidatePublicKeyFast(v.publicKey); err != v.fast {
+			t.Errorf("%s: fast: expected %v, got %v", v.name, v.fast, err)
+		}
+		if err := ValidatePublicKeySubgroup(v.publicKey); err != v.subgroup {
+			t.Errorf("%s: subgroup: expected %v, got %v", v.name, v.subgroup, err)
+		}
+		// Both tiers together are equivalent to ValidatePublicKey.
+		combined := v.fast
+		if combined == nil {
+			combined = v.subgroup
+		}
+		if err := ValidatePublicKey(v.publicKey); err != combined {
+			t.Errorf("%s: expected %v, got %v", v.name, combined, err)
+		}
+	}
+}
+
+func TestPublicKeyRangeBoundary(t *testing.T) {
+	modulusMinusOne := new(big.Int).Sub(modulus, one)
+	if err := Group14.checkPublicKeyRange(modulus); err != ErrPublicKeyOutOfRange {
+		t.Errorf("modulus: expected %v, got %v", ErrPublicKeyOutOfRange, err)
+	}
+	if err := Group14.checkPublicKeyRange(new(big.Int).Add(modulus, one)); err != ErrPublicKeyOutOfRange {
+		t.Errorf("modulus+1: expected %v, got %v", ErrPublicKeyOutOfRange, err)
+	}
+	if err := Group14.checkPublicKeyRange(modulusMinusOne); err != nil {
+		t.Errorf("modulus-1: expected in range, got %v", err)
+	}
+	// Negative values can't be decoded from bytes, but must not be reduced
+	// into range either: -1 is congruent to modulus-1, and -(modulus-2) to 2.
+	for _, y := range []*big.Int{big.NewInt(-1), new(big.Int).Neg(new(big.Int).Sub(modulus, big.NewInt(2)))} {
+		if err := Group14.validatePublicKey(y); err != ErrPublicKeyOutOfRange {
+			t.Errorf("%v: expected %v, got %v", y, ErrPublicKeyOutOfRange, err)
+		}
+	}
+	// modulus-1 passes the range check and is rejected by the subgroup
+	// check too, not only as degenerate.
+	if err := Group14.validatePublicKeySubgroup(modulusMinusOne); err != ErrPublicKeyNotInSubgroup {
+		t.Errorf("modulus-1: subgroup: expected %v, got %v", ErrPublicKeyNotInSubgroup, err)
+	}
+	if ErrPublicKeyTooLarge != ErrPublicKeyOutOfRange {
+		t.Errorf("ErrPublicKeyTooLarge is not an alias of ErrPublicKeyOutOfRange")
+	}
+}
+
+func TestJacobiCheck(t *testing.T) {
+	y := new(big.Int).SetBytes(golden.publicKey1)
+	// -1 is a non-residue as modulus = 3 mod 4, and 2 is a residue as
+	// modulus = 7 mod 8.
+	residues := []*big.Int{y, new(big.Int).Mod(new(big.Int).Lsh(y, 1), modulus)}
+	nonResidues := []*big.Int{new(big.Int).Sub(modulus, y), new(big.Int).Sub(modulus, big.NewInt(2))}
+	for i, r := range residues {
+		if big.Jacobi(r, modulus) != 1 {
+			t.Fatalf("residue %d: wrong Jacobi symbol", i)
+		}
+		if err := ValidatePublicKeyFast(publicKeyBytes(r)); err != nil {
+			t.Errorf("residue %d: fast: %v", i, err)
+		}
+		if err := ValidatePublicKey(publicKeyBytes(r)); err != nil {
+			t.Errorf("residue %d: %v", i, err)
+		}
+	}
+	for i, n := range nonResidues {
+		if big.Jacobi(n, modulus) != -1 {
+			t.Fatalf("non-residue %d: wrong Jacobi symbol", i)
+		}
+		if err := ValidatePublicKeyFast(publicKeyBytes(n)); err != ErrPublicKeyNotInSubgroup {
+			t.Errorf("non-residue %d: fast: expected %v, got %v", i, ErrPublicKeyNotInSubgroup, err)
+		}
+		// The exponentiation agrees.
+		if err := ValidatePublicKeySubgroup(publicKeyBytes(n)); err != ErrPublicKeyNotInSubgroup {
+			t.Errorf("non-residue %d: subgroup: expected %v, got %v", i, ErrPublicKeyNotInSubgroup, err)
+		}
+	}
+}
+
+// TestRejectModulusMinusOne checks that modulus-1, which generates the
+// subgroup of order 2, is rejected by every function that accepts a peer
+// public key, in every group.
+func TestRejectModulusMinusOne(t *testing.T) {
+	for _, v := range groups {
+		publicKey := new(big.Int).Sub(v.group.Modulus, one).FillBytes(make([]byte, v.group.PublicKeySize))
+		if err := v.group.ValidatePublicKey(publicKey); err != ErrDegeneratePublicKey {
+			t.Errorf("%s: ValidatePublicKey: expected %v, got %v", v.name, ErrDegeneratePublicKey, err)
+		}
+		if err := v.group.ValidatePublicKeyFast(publicKey); err != ErrDegeneratePublicKey {
+			t.Errorf("%s: ValidatePublicKeyFast: expected %v, got %v", v.name, ErrDegeneratePublicKey, err)
+		}
+		if err := v.group.ValidatePublicKeySubgroup(publicKey); err != ErrPublicKeyNotInSubgroup {
+			t.Errorf("%s: ValidatePublicKeySubgroup: expected %v, got %v", v.name, ErrPublicKeyNotInSubgroup, err)
+		}
+		if _, err := v.group.SharedKey(rand.Reader, publicKey, golden.privateKey1); err != ErrDegeneratePublicKey {
+			t.Errorf("%s: SharedKey: expected %v, got %v", v.name, ErrDegeneratePublicKey, err)
+		}
+		if _, err := v.group.SharedKeyUnblinded(publicKey, golden.privateKey1); err != ErrDegeneratePublicKey {
+			t.Errorf("%s: SharedKeyUnblinded: expected %v, got %v", v.name, ErrDegeneratePublicKey, err)
+		}
+		if _, err := v.group.NewPeerKey(publicKey); err != ErrDegeneratePublicKey {
+			t.Errorf("%s: NewPeerKey: expected %v, got %v", v.name, ErrDegeneratePublicKey, err)
+		}
+	}
+}
+
+func TestValidatePublicKeyStrict(t *testing.T) {
+	for _, publicKey := range [][]byte{golden.publicKey1, golden.publicKey2} {
+		if err := ValidatePublicKeyStrict(publicKey); err != nil {
+			t.Fatalf("rejected valid public key: %s", err)
+		}
+	}
+	for _, k := range []uint{1023, 1024, 1500, 2047} {
+		y := publicKeyBytes(new(big.Int).Lsh(one, k))
+		if err := ValidatePublicKey(y); err != nil {
+			t.Fatalf("2^%d: ValidatePublicKey: %s", k, err)
+		}
+		if err := ValidatePublicKeyStrict(y); err != ErrSmallPowerPublicKey {
+			t.Fatalf("2^%d: expected %v, got %v", k, ErrSmallPowerPublicKey, err)
+		}
+	}
+	// 2^2048 is reduced modulo p, so it is not in the rejected set.
+	y := publicKeyBytes(new(big.Int).Exp(big.NewInt(2), big.NewInt(2048), Modulus()))
+	if err := ValidatePublicKeyStrict(y); err != nil {
+		t.Fatalf("2^2048: %s", err)
+	}
+	if err := ValidatePublicKeyStrict(publicKeyBytes(big.NewInt(2))); err != ErrPublicKeyTooSmall {
+		t.Fatalf("generator: expected %v, got %v", ErrPublicKeyTooSmall, err)
+	}
+}
+
+func TestIsIdentity(t *testing.T) {
+	if !IsIdentity(publicKeyBytes(big.NewInt(1))) {
+		t.Errorf("padded 1 is not identity")
+	}
+	if !IsIdentity([]byte{1}) || !IsIdentity([]byte{0, 0, 1}) {
+		t.Errorf("short 1 is not identity")
+	}
+	if IsIdentity(publicKeyBytes(big.NewInt(0))) || IsIdentity(nil) {
+		t.Errorf("0 is identity")
+	}
+	if IsIdentity(golden.publicKey1) {
+		t.Errorf("public key is identity")
+	}
+	if IsIdentity([]byte{1, 0}) {
+		t.Errorf("256 is identity")
+	}
+}
+
+func TestDegenerateResult(t *testing.T) {
+	// golden.privateKey2 is odd, so (modulus-1)^(2^258 + privateKey) = modulus-1.
+	if golden.privateKey2[PrivateKeySize-1]&1 != 1 {
+		t.Fatalf("golden.privateKey2 must be odd")
+	}
+	bases := map[string]*big.Int{
+		"zero":      big.NewInt(0),
+		"one":       big.NewInt(1),
+		"modulus-1": new(big.Int).Sub(modulus, big.NewInt(1)),
+	}
+	for name, base := range bases {
+		_, err := Group14.blindedModExp(context.Background(), rand.Reader, Group14.baseExp(base), golden.privateKey2)
+		if !errors.Is(err, ErrDegenerateResult) {
+			t.Errorf("%s: expected degenerate result error, got %v", name, err)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	sharedKey := append([]byte(nil), golden.sharedKey...)
+	if !Equal(sharedKey, golden.sharedKey) {
+		t.Errorf("equal keys are not equal")
+	}
+	sharedKey[len(sharedKey)-1] ^= 1
+	if Equal(sharedKey, golden.sharedKey) {
+		t.Errorf("different keys are equal")
+	}
+	if Equal(golden.sharedKey[:10], golden.sharedKey) {
+		t.Errorf("keys of different lengths are equal")
+	}
+	if !Equal(nil, []byte{}) {
+		t.Errorf("empty keys are not equal")
+	}
+}
+
+func TestConstantTimeSelect(t *testing.T) {
+	a := []byte{1, 2, 3}
+	b := []byte{4, 5, 6}
+	if r := ConstantTimeSelect(1, a, b); !bytes.Equal(r, a) {
+		t.Errorf("condition 1: expected %v, got %v", a, r)
+	}
+	if r := ConstantTimeSelect(0, a, b); !bytes.Equal(r, b) {
+		t.Errorf("condition 0: expected %v, got %v", b, r)
+	}
+	// The result is a copy.
+	r := ConstantTimeSelect(1, a, b)
+	r[0] = 0
+	if a[0] != 1 {
+		t.Errorf("result aliases input")
+	}
+	if r := ConstantTimeSelect(1, nil, []byte{}); len(r) != 0 {
+		t.Errorf("empty: got %v", r)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("no panic for different lengths")
+		}
+	}()
+	ConstantTimeSelect(1, a, b[:2])
+}
+
+func TestClonePrivateKey(t *testing.T) {
+	privateKey := append([]byte(nil), golden.privateKey1...)
+	clone := ClonePrivateKey(privateKey)
+	Zeroize(clone)
+	if !bytes.Equal(privateKey, golden.privateKey1) {
+		t.Fatalf("zeroizing clone changed private key")
+	}
+	if ClonePrivateKey(nil) != nil {
+		t.Fatalf("clone of nil is not nil")
+	}
+	if c := ClonePrivateKey([]byte{}); c == nil || len(c) != 0 {
+		t.Fatalf("clone of empty key is %v", c)
+	}
+}
+
+func TestZeroize(t *testing.T) {
+	privateKey := append([]byte(nil), golden.privateKey1...)
+	Zeroize(privateKey)
+	if !bytes.Equal(privateKey, make([]byte, PrivateKeySize)) {
+		t.Fatalf("private key is not zeroized: %x", privateKey)
+	}
+}
+
+func TestWipeInt(t *testing.T) {
+	x := new(big.Int).SetBytes(golden.privateKey1)
+	words := x.Bits()
+	wipeInt(x)
+	if x.Sign() != 0 {
+		t.Fatalf("wiped value is not zero: %s", x)
+	}
+	for i, w := range words {
+		if w != 0 {
+			t.Fatalf("word %d is not wiped: %x", i, w)
+		}
+	}
+}
+
+func TestPutInt(t *testing.T) {
+	x := getInt().SetBytes(golden.privateKey1)
+	words := x.Bits()
+	putInt(x)
+	for i, w := range words {
+		if w != 0 {
+			t.Fatalf("word %d is not wiped: %x", i, w)
+		}
+	}
+	if y := getInt(); y.Sign() != 0 {
+		t.Fatalf("pooled value is not zero: %s", y)
+	}
+}
+
+func FuzzSharedKey(f *testing.F) {
+	f.Add(golden.publicKey1)
+	f.Add(golden.publicKey1[1:])
+	f.Add(publicKeyBytes(big.NewInt(1)))
+	f.Add(publicKeyBytes(modulus))
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, publicKey []byte) {
+		sharedKey, err := SharedKey(rand.Reader, publicKey, golden.privateKey1)
+		if err != nil {
+			if sharedKey != nil {
+				t.Fatalf("returned shared key with error %v", err)
+			}
+			return
+		}
+		if len(sharedKey) != SharedKeySize {
+			t.Fatalf("shared key is %d bytes", len(sharedKey))
+		}
+	})
+}
+
+func FuzzGeneratePublicKey(f *testing.F) {
+	f.Add(golden.privateKey1)
+	f.Add(golden.privateKey1[1:])
+	f.Add(make([]byte, PrivateKeySize))
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, privateKey []byte) {
+		publicKey, err := GeneratePublicKey(rand.Reader, privateKey)
+		if err != nil {
+			if publicKey != nil {
+				t.Fatalf("returned public key with error %v", err)
+			}
+			return
+		}
+		if len(publicKey) != PublicKeySize {
+			t.Fatalf("public key is %d bytes", len(publicKey))
+		}
+		if err := ValidatePublicKey(publicKey); err != nil {
+			t.Fatalf("generated invalid public key: %s", err)
+		}
+	})
+}
+
 func BenchmarkCompute(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		SharedKey(rand.Reader, golden.publicKey1, golden.privateKey1)
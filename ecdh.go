@@ -0,0 +1,104 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import "io"
+
+// DHPrivateKey is a group #14 private key with an API shaped like
+// crypto/ecdh.PrivateKey, so that code written against crypto/ecdh can use
+// this group with minimal changes:
+//
+//	crypto/ecdh                    dhgroup14
+//	curve.GenerateKey(rand)        GenerateDHKey(rand)
+//	curve.NewPrivateKey(key)       NewPrivateKey(rand, key)
+//	curve.NewPublicKey(key)        NewPublicKey(key)
+//	(*PrivateKey).PublicKey()      (*DHPrivateKey).PublicKey()
+//	(*PrivateKey).ECDH(remote)     (*DHPrivateKey).DH(remote)
+//	(*PrivateKey).Bytes()          (*DHPrivateKey).Bytes()
+//	(*PublicKey).Bytes()           (*DHPublicKey).Bytes()
+//
+// Unlike crypto/ecdh, exponentiations are blinded, so a DHPrivateKey
+// carries the randomness source given at construction.
+type DHPrivateKey struct {
+	rand       io.Reader
+	privateKey PrivateKey
+	publicKey  DHPublicKey
+}
+
+// DHPublicKey is a group #14 public key with an API shaped like
+// crypto/ecdh.PublicKey. See DHPrivateKey.
+type DHPublicKey struct {
+	publicKey PublicKey
+}
+
+// GenerateDHKey generates a new random DHPrivateKey.
+//
+// Random bytes are read from rand, which must be set to a CSPRNG, such as
+// crypto/rand.Reader, and are used by the returned key for blinding. If rand
+// is nil, crypto/rand.Reader is used.
+func GenerateDHKey(rand io.Reader) (*DHPrivateKey, error) {
+	rand = randReader(rand)
+	publicKey, privateKey, err := GenerateKey(rand)
+	if err != nil {
+		return nil, err
+	}
+	return &DHPrivateKey{
+		rand:       rand,
+		privateKey: *privateKey,
+		publicKey:  DHPublicKey{*publicKey},
+	}, nil
+}
+
+// NewPrivateKey returns a DHPrivateKey for the PrivateKeySize-byte key and
+// computes its public key.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func NewPrivateKey(rand io.Reader, key []byte) (*DHPrivateKey, error) {
+	rand = randReader(rand)
+	publicKey, err := Group14.GeneratePublicKey(rand, key)
+	if err != nil {
+		return nil, err
+	}
+	k := &DHPrivateKey{rand: rand}
+	copy(k.privateKey[:], key)
+	copy(k.publicKey.publicKey[:], publicKey)
+	return k, nil
+}
+
+// NewPublicKey returns a DHPublicKey for the PublicKeySize-byte key after
+// validating it with ValidatePublicKey.
+func NewPublicKey(key []byte) (*DHPublicKey, error) {
+	if err := Group14.ValidatePublicKey(key); err != nil {
+		return nil, err
+	}
+	k := new(DHPublicKey)
+	copy(k.publicKey[:], key)
+	return k, nil
+}
+
+// PublicKey returns the public key corresponding to k.
+func (k *DHPrivateKey) PublicKey() *DHPublicKey {
+	publicKey := k.publicKey
+	return &publicKey
+}
+
+// DH returns the shared key between k and remote.
+func (k *DHPrivateKey) DH(remote *DHPublicKey) ([]byte, error) {
+	return k.privateKey.SharedKey(k.rand, &remote.publicKey)
+}
+
+// Bytes returns a copy of the private key.
+func (k *DHPrivateKey) Bytes() []byte {
+	return append([]byte(nil), k.privateKey[:]...)
+}
+
+// Bytes returns a copy of the public key.
+func (k *DHPublicKey) Bytes() []byte {
+	return append([]byte(nil), k.publicKey[:]...)
+}
@@ -0,0 +1,63 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestDHKeyAdapter(t *testing.T) {
+	k1, err := NewPrivateKey(rand.Reader, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("new private key: %s", err)
+	}
+	if !bytes.Equal(k1.PublicKey().Bytes(), golden.publicKey1) {
+		t.Fatalf("wrong public key")
+	}
+	if !bytes.Equal(k1.Bytes(), golden.privateKey1) {
+		t.Fatalf("wrong private key bytes")
+	}
+	remote, err := NewPublicKey(golden.publicKey2)
+	if err != nil {
+		t.Fatalf("new public key: %s", err)
+	}
+	sharedKey, err := k1.DH(remote)
+	if err != nil {
+		t.Fatalf("DH: %s", err)
+	}
+	if !bytes.Equal(sharedKey, golden.sharedKey) {
+		t.Fatalf(`expecting "%x", got "%x"`, golden.sharedKey, sharedKey)
+	}
+
+	k2, err := GenerateDHKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %s", err)
+	}
+	sharedKey1, err := k1.DH(k2.PublicKey())
+	if err != nil {
+		t.Fatalf("DH 1: %s", err)
+	}
+	sharedKey2, err := k2.DH(k1.PublicKey())
+	if err != nil {
+		t.Fatalf("DH 2: %s", err)
+	}
+	if !bytes.Equal(sharedKey1, sharedKey2) {
+		t.Fatalf("two shared keys are not equal!")
+	}
+}
+
+func TestDHKeyAdapterErrors(t *testing.T) {
+	if _, err := NewPrivateKey(rand.Reader, golden.privateKey1[1:]); err != ErrWrongPrivateKeySize {
+		t.Errorf("expected %v, got %v", ErrWrongPrivateKeySize, err)
+	}
+	if _, err := NewPublicKey(golden.publicKey1[1:]); err != ErrWrongPublicKeySize {
+		t.Errorf("expected %v, got %v", ErrWrongPublicKeySize, err)
+	}
+}
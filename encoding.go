@@ -0,0 +1,80 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// EncodeToHex returns the hexadecimal encoding of key.
+func EncodeToHex(key []byte) string {
+	return hex.EncodeToString(key)
+}
+
+// DecodeHexPublicKey decodes a hexadecimal public key and checks that it is
+// PublicKeySize bytes.
+func DecodeHexPublicKey(s string) ([]byte, error) {
+	publicKey, err := decodeHex(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(publicKey) != PublicKeySize {
+		return nil, ErrWrongPublicKeySize
+	}
+	return publicKey, nil
+}
+
+// ParsePublicKey parses a big-endian public key of DefaultGroup that may have
+// had leading zero bytes stripped, as some implementations do. Inputs shorter
+// than DefaultGroup.PublicKeySize bytes are left-padded with zeros. It
+// returns ErrWrongPublicKeySize for longer inputs and ErrPublicKeyOutOfRange
+// for values not less than the modulus. The result is a new
+// DefaultGroup.PublicKeySize-byte slice, which still needs to be validated,
+// for example by SharedKey.
+func ParsePublicKey(b []byte) ([]byte, error) {
+	g := DefaultGroup
+	if len(b) > g.PublicKeySize {
+		return nil, ErrWrongPublicKeySize
+	}
+	publicKey := make([]byte, g.PublicKeySize)
+	copy(publicKey[g.PublicKeySize-len(b):], b)
+	if new(big.Int).SetBytes(publicKey).Cmp(g.Modulus) >= 0 {
+		return nil, ErrPublicKeyOutOfRange
+	}
+	return publicKey, nil
+}
+
+// DecodeHexPrivateKey decodes a hexadecimal private key and checks that it
+// is PrivateKeySize bytes.
+func DecodeHexPrivateKey(s string) ([]byte, error) {
+	privateKey, err := decodeHex(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(privateKey) != PrivateKeySize {
+		Zeroize(privateKey)
+		return nil, ErrWrongPrivateKeySize
+	}
+	return privateKey, nil
+}
+
+// decodeHex decodes s into a new slice. Since s may hold a private key, the
+// copy of s and, on error, the partly decoded bytes are zeroized.
+func decodeHex(s string) ([]byte, error) {
+	src := []byte(s)
+	defer Zeroize(src)
+	b := make([]byte, hex.DecodedLen(len(src)))
+	n, err := hex.Decode(b, src)
+	if err != nil {
+		Zeroize(b)
+		return nil, fmt.Errorf("dhgroup14: invalid hex key: %w", err)
+	}
+	return b[:n], nil
+}
@@ -0,0 +1,114 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestHexRoundTrip(t *testing.T) {
+	publicKey, err := DecodeHexPublicKey(EncodeToHex(golden.publicKey1))
+	if err != nil {
+		t.Fatalf("decode public key: %s", err)
+	}
+	if !bytes.Equal(publicKey, golden.publicKey1) {
+		t.Fatalf("decoded wrong public key: %x", publicKey)
+	}
+	privateKey, err := DecodeHexPrivateKey(EncodeToHex(golden.privateKey1))
+	if err != nil {
+		t.Fatalf("decode private key: %s", err)
+	}
+	if !bytes.Equal(privateKey, golden.privateKey1) {
+		t.Fatalf("decoded wrong private key: %x", privateKey)
+	}
+}
+
+func TestHexDecodeErrors(t *testing.T) {
+	privateKeyHex := EncodeToHex(golden.privateKey1)
+	publicKeyHex := EncodeToHex(golden.publicKey1)
+	bad := map[string]string{
+		"odd length":       privateKeyHex[1:],
+		"non-hex":          "zz" + privateKeyHex[2:],
+		"trailing non-hex": privateKeyHex[:len(privateKeyHex)-2] + "zz",
+		"wrong size":       publicKeyHex,
+		"empty":            "",
+	}
+	for name, s := range bad {
+		if _, err := DecodeHexPrivateKey(s); err == nil {
+			t.Errorf("private key: %s: expected error", name)
+		}
+	}
+	bad["wrong size"] = privateKeyHex
+	for name, s := range bad {
+		if _, err := DecodeHexPublicKey(s); err == nil {
+			t.Errorf("public key: %s: expected error", name)
+		}
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	publicKey, err := ParsePublicKey(golden.publicKey1)
+	if err != nil {
+		t.Fatalf("256-byte key: %s", err)
+	}
+	if !bytes.Equal(publicKey, golden.publicKey1) {
+		t.Fatalf("256-byte key: wrong public key")
+	}
+	publicKey[0] ^= 1
+	if publicKey[0] == golden.publicKey1[0] {
+		t.Fatalf("256-byte key: result aliases input")
+	}
+
+	full, err := GeneratePublicKey(rand.Reader, shortPublicKeyPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full[0] != 0 {
+		t.Fatalf("expected public key with leading zero byte")
+	}
+	publicKey, err = ParsePublicKey(full[1:])
+	if err != nil {
+		t.Fatalf("255-byte key: %s", err)
+	}
+	if !bytes.Equal(publicKey, full) {
+		t.Fatalf("255-byte key: wrong public key")
+	}
+	if _, err := SharedKey(rand.Reader, publicKey, golden.privateKey1); err != nil {
+		t.Fatalf("255-byte key: SharedKey: %s", err)
+	}
+
+	if _, err := ParsePublicKey(append([]byte{0}, golden.publicKey1...)); err != ErrWrongPublicKeySize {
+		t.Errorf("257-byte key: expected %v, got %v", ErrWrongPublicKeySize, err)
+	}
+	if _, err := ParsePublicKey(ModulusBytes()); err != ErrPublicKeyOutOfRange {
+		t.Errorf("modulus: expected %v, got %v", ErrPublicKeyOutOfRange, err)
+	}
+}
+
+func TestParsePublicKeyDefaultGroup(t *testing.T) {
+	defer func(g *Group) { DefaultGroup = g }(DefaultGroup)
+	DefaultGroup = Group16
+
+	full := make([]byte, Group16.PublicKeySize)
+	full[1] = 2
+	publicKey, err := ParsePublicKey(full[1:])
+	if err != nil {
+		t.Fatalf("511-byte key: %s", err)
+	}
+	if !bytes.Equal(publicKey, full) {
+		t.Fatalf("511-byte key: wrong public key")
+	}
+	if _, err := ParsePublicKey(append([]byte{0}, full...)); err != ErrWrongPublicKeySize {
+		t.Errorf("513-byte key: expected %v, got %v", ErrWrongPublicKeySize, err)
+	}
+	if _, err := ParsePublicKey(Group16.Modulus.Bytes()); err != ErrPublicKeyOutOfRange {
+		t.Errorf("modulus: expected %v, got %v", ErrPublicKeyOutOfRange, err)
+	}
+}
@@ -0,0 +1,54 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import "io"
+
+// GeneratePublicKeyLE is like GeneratePublicKey, but privateKey and the
+// returned public key are little-endian: their first byte is the least
+// significant. The public key is always PublicKeySize bytes long,
+// right-padded with zero bytes.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func GeneratePublicKeyLE(rand io.Reader, privateKey []byte) (publicKey []byte, err error) {
+	priv := reverseBytes(privateKey)
+	defer Zeroize(priv)
+	publicKey, err = GeneratePublicKey(rand, priv)
+	if err != nil {
+		return nil, err
+	}
+	return reverseBytes(publicKey), nil
+}
+
+// SharedKeyLE is like SharedKey, but theirPublicKey, myPrivateKey and the
+// returned shared key are little-endian: their first byte is the least
+// significant. The shared key is always SharedKeySize bytes long,
+// right-padded with zero bytes.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func SharedKeyLE(rand io.Reader, theirPublicKey, myPrivateKey []byte) (sharedKey []byte, err error) {
+	priv := reverseBytes(myPrivateKey)
+	defer Zeroize(priv)
+	sharedKey, err = SharedKey(rand, reverseBytes(theirPublicKey), priv)
+	if err != nil {
+		return nil, err
+	}
+	defer Zeroize(sharedKey)
+	return reverseBytes(sharedKey), nil
+}
+
+// reverseBytes returns a copy of b with the order of bytes reversed.
+func reverseBytes(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i, c := range b {
+		r[len(b)-1-i] = c
+	}
+	return r
+}
@@ -0,0 +1,74 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestLittleEndian(t *testing.T) {
+	publicKey, err := GeneratePublicKeyLE(rand.Reader, reverseBytes(golden.privateKey1))
+	if err != nil {
+		t.Fatalf("generate public key: %s", err)
+	}
+	if !bytes.Equal(publicKey, reverseBytes(golden.publicKey1)) {
+		t.Fatalf("little-endian public key doesn't match")
+	}
+	sharedKey, err := SharedKeyLE(rand.Reader, reverseBytes(golden.publicKey2), reverseBytes(golden.privateKey1))
+	if err != nil {
+		t.Fatalf("shared key: %s", err)
+	}
+	if !bytes.Equal(sharedKey, reverseBytes(golden.sharedKey)) {
+		t.Fatalf("little-endian shared key doesn't match")
+	}
+	// Big-endian keys passed as little-endian are different keys, which
+	// may or may not be valid.
+	if sharedKey, err := SharedKeyLE(rand.Reader, golden.publicKey2, golden.privateKey1); err == nil && bytes.Equal(sharedKey, reverseBytes(golden.sharedKey)) {
+		t.Fatalf("byte order of keys ignored")
+	}
+}
+
+func TestMixedEndian(t *testing.T) {
+	// One side uses big-endian keys, the other little-endian.
+	publicKeyBE, privateKeyBE, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, privateKeyLE, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKeyLE, err := GeneratePublicKeyLE(rand.Reader, privateKeyLE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sharedKeyBE, err := SharedKey(rand.Reader, reverseBytes(publicKeyLE), privateKeyBE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sharedKeyLE, err := SharedKeyLE(rand.Reader, reverseBytes(publicKeyBE), privateKeyLE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sharedKeyBE, reverseBytes(sharedKeyLE)) {
+		t.Fatalf("shared keys don't match")
+	}
+}
+
+func TestReverseBytes(t *testing.T) {
+	b := []byte{1, 2, 3}
+	r := reverseBytes(b)
+	if !bytes.Equal(r, []byte{3, 2, 1}) {
+		t.Fatalf("got %v", r)
+	}
+	if !bytes.Equal(b, []byte{1, 2, 3}) {
+		t.Fatalf("input modified")
+	}
+}
@@ -0,0 +1,171 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+var (
+	// ErrEphemeralClosed is returned when using a closed Ephemeral or
+	// StaticKey.
+	ErrEphemeralClosed = errors.New("dhgroup14: ephemeral key is closed")
+
+	// ErrEphemeralReused is returned when computing a second shared key
+	// with an Ephemeral.
+	ErrEphemeralReused = errors.New("dhgroup14: ephemeral key is reused")
+)
+
+// Ephemeral is a single-use ephemeral key pair. Its private key is used for
+// at most one shared key and is zeroized right after computing it, so that
+// the shared key can't be recomputed later, which is what provides forward
+// secrecy. Use StaticKey for long-term keys that are used for many shared
+// keys. Ephemeral is safe for concurrent use.
+type Ephemeral struct {
+	rand io.Reader
+
+	mu         sync.Mutex
+	closed     bool
+	used       bool
+	privateKey []byte
+	publicKey  []byte
+}
+
+// NewEphemeral generates a new ephemeral key pair.
+//
+// Random bytes are read from rand, which must be set to a CSPRNG, such as
+// crypto/rand.Reader, and are also used for blinding in SharedKey. If rand is
+// nil, crypto/rand.Reader is used.
+func NewEphemeral(rand io.Reader) (*Ephemeral, error) {
+	rand = randReader(rand)
+	publicKey, privateKey, err := GenerateKeyPair(rand)
+	if err != nil {
+		return nil, err
+	}
+	return &Ephemeral{
+		rand:       rand,
+		privateKey: privateKey,
+		publicKey:  publicKey,
+	}, nil
+}
+
+// Public returns a copy of the public key.
+func (e *Ephemeral) Public() []byte {
+	return append([]byte(nil), e.publicKey...)
+}
+
+// SharedKey returns a shared key between theirPublicKey and the ephemeral
+// private key, and then zeroizes the private key. Further calls return
+// ErrEphemeralReused. If computing the shared key fails, for example because
+// theirPublicKey is invalid, the private key is kept and SharedKey may be
+// called again. SharedKey returns ErrEphemeralClosed after Close.
+func (e *Ephemeral) SharedKey(theirPublicKey []byte) ([]byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return nil, ErrEphemeralClosed
+	}
+	if e.used {
+		return nil, ErrEphemeralReused
+	}
+	sharedKey, err := SharedKey(e.rand, theirPublicKey, e.privateKey)
+	if err != nil {
+		return nil, err
+	}
+	Zeroize(e.privateKey)
+	e.used = true
+	return sharedKey, nil
+}
+
+// Close zeroizes the private key. It is safe to call Close multiple times.
+func (e *Ephemeral) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	Zeroize(e.privateKey)
+	e.closed = true
+	return nil
+}
+
+// StaticKey is a long-term key pair, which may be used for any number of
+// shared keys until Close is called. Shared keys computed with a StaticKey
+// can be recomputed by anyone who later obtains its private key; use
+// Ephemeral for keys that should have forward secrecy. StaticKey is safe for
+// concurrent use.
+type StaticKey struct {
+	rand io.Reader
+
+	mu         sync.Mutex
+	closed     bool
+	privateKey []byte
+	publicKey  []byte
+}
+
+// NewStaticKey generates a new long-term key pair.
+//
+// Random bytes are read from rand, which must be set to a CSPRNG, such as
+// crypto/rand.Reader, and are also used for blinding in SharedKey. If rand is
+// nil, crypto/rand.Reader is used.
+func NewStaticKey(rand io.Reader) (*StaticKey, error) {
+	rand = randReader(rand)
+	publicKey, privateKey, err := GenerateKeyPair(rand)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticKey{
+		rand:       rand,
+		privateKey: privateKey,
+		publicKey:  publicKey,
+	}, nil
+}
+
+// NewStaticKeyFromPrivate returns a StaticKey for an existing private key,
+// such as one loaded from storage, computing its public key. privateKey is
+// copied.
+//
+// Random bytes for blinding are read from rand, which must be set to a
+// CSPRNG, such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is
+// used.
+func NewStaticKeyFromPrivate(rand io.Reader, privateKey []byte) (*StaticKey, error) {
+	rand = randReader(rand)
+	publicKey, err := GeneratePublicKey(rand, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticKey{
+		rand:       rand,
+		privateKey: ClonePrivateKey(privateKey),
+		publicKey:  publicKey,
+	}, nil
+}
+
+// Public returns a copy of the public key.
+func (k *StaticKey) Public() []byte {
+	return append([]byte(nil), k.publicKey...)
+}
+
+// SharedKey returns a shared key between theirPublicKey and the static
+// private key. It returns ErrEphemeralClosed after Close.
+func (k *StaticKey) SharedKey(theirPublicKey []byte) ([]byte, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.closed {
+		return nil, ErrEphemeralClosed
+	}
+	return SharedKey(k.rand, theirPublicKey, k.privateKey)
+}
+
+// Close zeroizes the private key. It is safe to call Close multiple times.
+func (k *StaticKey) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	Zeroize(k.privateKey)
+	k.closed = true
+	return nil
+}
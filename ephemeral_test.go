@@ -0,0 +1,118 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestEphemeral(t *testing.T) {
+	e1, err := NewEphemeral(rand.Reader)
+	if err != nil {
+		t.Fatalf("new ephemeral 1: %s", err)
+	}
+	e2, err := NewEphemeral(nil)
+	if err != nil {
+		t.Fatalf("new ephemeral 2: %s", err)
+	}
+	sharedKey1, err := e1.SharedKey(e2.Public())
+	if err != nil {
+		t.Fatalf("compute shared key 1: %s", err)
+	}
+	sharedKey2, err := e2.SharedKey(e1.Public())
+	if err != nil {
+		t.Fatalf("compute shared key 2: %s", err)
+	}
+	if !bytes.Equal(sharedKey1, sharedKey2) {
+		t.Fatalf("two shared keys are not equal!")
+	}
+
+	if !bytes.Equal(e1.privateKey, make([]byte, PrivateKeySize)) {
+		t.Fatalf("private key is not zeroized after use: %x", e1.privateKey)
+	}
+
+	privateKey := e1.privateKey
+	for i := 0; i < 2; i++ {
+		if err := e1.Close(); err != nil {
+			t.Fatalf("close %d: %s", i, err)
+		}
+	}
+	if !bytes.Equal(privateKey, make([]byte, PrivateKeySize)) {
+		t.Fatalf("private key is not zeroized: %x", privateKey)
+	}
+	if _, err := e1.SharedKey(e2.Public()); err != ErrEphemeralClosed {
+		t.Fatalf("expected %v, got %v", ErrEphemeralClosed, err)
+	}
+}
+
+func TestEphemeralReused(t *testing.T) {
+	e, err := NewEphemeral(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+	// A failed computation doesn't use up the key.
+	if _, err := e.SharedKey(golden.publicKey2[1:]); err != ErrWrongPublicKeySize {
+		t.Fatalf("expected %v, got %v", ErrWrongPublicKeySize, err)
+	}
+	if _, err := e.SharedKey(golden.publicKey2); err != nil {
+		t.Fatalf("first use: %s", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := e.SharedKey(golden.publicKey2); err != ErrEphemeralReused {
+			t.Fatalf("reuse %d: expected %v, got %v", i, ErrEphemeralReused, err)
+		}
+	}
+	if _, err := e.SharedKey(golden.publicKey1); err != ErrEphemeralReused {
+		t.Fatalf("reuse with other peer: expected %v, got %v", ErrEphemeralReused, err)
+	}
+}
+
+func TestStaticKey(t *testing.T) {
+	k, err := NewStaticKeyFromPrivate(rand.Reader, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("new static key: %s", err)
+	}
+	if !bytes.Equal(k.Public(), golden.publicKey1) {
+		t.Fatalf("public key doesn't match")
+	}
+	// Static keys may be reused.
+	for i := 0; i < 2; i++ {
+		sharedKey, err := k.SharedKey(golden.publicKey2)
+		if err != nil {
+			t.Fatalf("use %d: %s", i, err)
+		}
+		if !bytes.Equal(sharedKey, golden.sharedKey) {
+			t.Fatalf("use %d: shared key doesn't match", i)
+		}
+	}
+	privateKey := k.privateKey
+	if err := k.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(privateKey, make([]byte, PrivateKeySize)) {
+		t.Fatalf("private key is not zeroized: %x", privateKey)
+	}
+	if _, err := k.SharedKey(golden.publicKey2); err != ErrEphemeralClosed {
+		t.Fatalf("expected %v, got %v", ErrEphemeralClosed, err)
+	}
+
+	k, err = NewStaticKey(nil)
+	if err != nil {
+		t.Fatalf("new static key: %s", err)
+	}
+	defer k.Close()
+	if _, err := k.SharedKey(golden.publicKey2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewStaticKeyFromPrivate(rand.Reader, golden.privateKey1[1:]); err != ErrWrongPrivateKeySize {
+		t.Fatalf("expected %v, got %v", ErrWrongPrivateKeySize, err)
+	}
+}
@@ -0,0 +1,120 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+)
+
+// This example shows a full two-party key exchange: both parties generate
+// key pairs, exchange public keys and compute the same shared key.
+func Example_keyExchange() {
+	// Alice and Bob generate key pairs.
+	alicePublic, alicePrivate, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	bobPublic, bobPrivate, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	// They exchange public keys and compute shared keys.
+	aliceShared, err := SharedKey(rand.Reader, bobPublic, alicePrivate)
+	if err != nil {
+		panic(err)
+	}
+	bobShared, err := SharedKey(rand.Reader, alicePublic, bobPrivate)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(len(aliceShared), bytes.Equal(aliceShared, bobShared))
+	// Output: 256 true
+}
+
+// This example shows the same exchange with typed keys.
+func ExampleGenerateKey() {
+	alicePublic, alicePrivate, err := GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	bobPublic, bobPrivate, err := GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	aliceShared, err := alicePrivate.SharedKey(rand.Reader, bobPublic)
+	if err != nil {
+		panic(err)
+	}
+	bobShared, err := bobPrivate.SharedKey(rand.Reader, alicePublic)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(bytes.Equal(aliceShared, bobShared))
+	// Output: true
+}
+
+// This example derives a 32-byte session key instead of using the raw
+// shared key.
+func ExampleDeriveKey() {
+	alicePublic, alicePrivate, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	bobPublic, bobPrivate, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	info := []byte("example session key")
+	aliceKey, err := DeriveKey(rand.Reader, bobPublic, alicePrivate, nil, info, 32)
+	if err != nil {
+		panic(err)
+	}
+	bobKey, err := DeriveKey(rand.Reader, alicePublic, bobPrivate, nil, info, 32)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(len(aliceKey), Equal(aliceKey, bobKey))
+	// Output: 32 true
+}
+
+// An invalid public key received from a peer is rejected.
+func ExampleValidatePublicKey() {
+	err := ValidatePublicKey(make([]byte, PublicKeySize))
+	fmt.Println(err)
+	// Output: dhgroup14: public key is degenerate
+}
+
+func ExampleAgree() {
+	alicePublic, alicePrivate, err := GenerateKeyPair(nil)
+	if err != nil {
+		panic(err)
+	}
+	bobPublic, bobPrivate, err := GenerateKeyPair(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	aliceSecret, err := Agree(nil, bobPublic, alicePrivate)
+	if err != nil {
+		panic(err)
+	}
+	bobSecret, err := Agree(nil, alicePublic, bobPrivate)
+	if err != nil {
+		panic(err)
+	}
+
+	info := []byte("example session key")
+	aliceKey, _ := aliceSecret.Derive(nil, info, 32)
+	bobKey, _ := bobSecret.Derive(nil, info, 32)
+	fmt.Println(len(aliceKey), Equal(aliceKey, bobKey))
+	// Output: 32 true
+}
@@ -0,0 +1,101 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"crypto/subtle"
+	"math/big"
+)
+
+// fixedBaseWindow is the window size in bits of fixedBaseTable.
+const fixedBaseWindow = 4
+
+// fixedBaseTable holds precomputed powers of a fixed base for windowed
+// exponentiation: entries[i][d] is base^(d * 2^(fixedBaseWindow*i)) mod
+// modulus, stored as a fixed-length little-endian word slice.
+//
+// An exponent then needs one multiplication per window instead of one
+// squaring per bit.
+type fixedBaseTable struct {
+	base    *big.Int
+	modulus *big.Int
+	bits    int // maximum exponent bit length
+	words   int // entry length in words
+	entries [][][]big.Word
+}
+
+func newFixedBaseTable(base, modulus *big.Int, bits int) *fixedBaseTable {
+	t := &fixedBaseTable{
+		base:    base,
+		modulus: modulus,
+		bits:    bits,
+		words:   len(modulus.Bits()),
+	}
+	windows := (bits + fixedBaseWindow - 1) / fixedBaseWindow
+	t.entries = make([][][]big.Word, windows)
+	b := new(big.Int).Mod(base, modulus) // base^(2^(fixedBaseWindow*i))
+	x := new(big.Int)
+	for i := range t.entries {
+		t.entries[i] = make([][]big.Word, 1<<fixedBaseWindow)
+		x.SetInt64(1)
+		for d := range t.entries[i] {
+			t.entries[i][d] = t.pad(x)
+			x.Mul(x, b)
+			x.Mod(x, modulus)
+		}
+		for j := 0; j < fixedBaseWindow; j++ {
+			b.Mul(b, b)
+			b.Mod(b, modulus)
+		}
+	}
+	return t
+}
+
+// pad returns the words of x, which must be less than modulus, padded to
+// t.words.
+func (t *fixedBaseTable) pad(x *big.Int) []big.Word {
+	w := make([]big.Word, t.words)
+	copy(w, x.Bits())
+	return w
+}
+
+// exp sets z to base^e mod modulus and returns z. Exponents that are
+// negative or longer than t.bits are passed to big.Int.Exp.
+//
+// Table entries are selected by scanning the whole window row, so that
+// memory access pattern does not depend on e.
+func (t *fixedBaseTable) exp(z, e *big.Int) *big.Int {
+	if e.Sign() < 0 || e.BitLen() > t.bits {
+		return z.Exp(t.base, e, t.modulus)
+	}
+	sel := make([]big.Word, t.words)
+	y := new(big.Int)
+	z.SetInt64(1)
+	for i, row := range t.entries {
+		d := 0
+		for j := fixedBaseWindow - 1; j >= 0; j-- {
+			d = d<<1 | int(e.Bit(i*fixedBaseWindow+j))
+		}
+		for k := range sel {
+			sel[k] = 0
+		}
+		for c, entry := range row {
+			mask := -big.Word(subtle.ConstantTimeEq(int32(c), int32(d)))
+			for k, w := range entry {
+				sel[k] |= w & mask
+			}
+		}
+		y.SetBits(sel)
+		z.Mul(z, y)
+		z.Mod(z, t.modulus)
+	}
+	for k := range sel {
+		sel[k] = 0
+	}
+	return z
+}
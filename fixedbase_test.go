@@ -0,0 +1,62 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestFixedBaseTable(t *testing.T) {
+	table := newFixedBaseTable(generator, modulus, 259)
+	limit := new(big.Int).Lsh(one, 259)
+	exponents := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		new(big.Int).Sub(limit, one),
+		// Fall back to big.Int.Exp.
+		limit,
+		new(big.Int).Lsh(limit, 10),
+		big.NewInt(-5),
+	}
+	for i := 0; i < 10; i++ {
+		e, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exponents = append(exponents, e)
+	}
+	for _, e := range exponents {
+		got := table.exp(new(big.Int), e)
+		expected := new(big.Int).Exp(generator, e, modulus)
+		if got.Cmp(expected) != 0 {
+			t.Fatalf("2^%s: expected %s, got %s", e, expected, got)
+		}
+	}
+}
+
+func benchmarkGeneratorExp(b *testing.B, exp expFunc) {
+	e, err := rand.Int(rand.Reader, new(big.Int).Lsh(one, 258))
+	if err != nil {
+		b.Fatal(err)
+	}
+	z := new(big.Int)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		exp(z, e)
+	}
+}
+
+func BenchmarkGeneratorExpStandard(b *testing.B) {
+	benchmarkGeneratorExp(b, Group14.baseExp(generator))
+}
+
+func BenchmarkGeneratorExpFixedBase(b *testing.B) {
+	benchmarkGeneratorExp(b, Group14.generatorExp())
+}
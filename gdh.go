@@ -0,0 +1,118 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	// ErrTooFewParties is returned by ComputeGroupKey if there are fewer
+	// than two parties.
+	ErrTooFewParties = errors.New("dhgroup14: group key agreement needs at least two parties")
+
+	errGroupKeyMismatch = errors.New("dhgroup14: parties computed different group keys")
+)
+
+// GroupAgreement is a participant in iterated Diffie-Hellman (GDH) key
+// agreement among n parties. Each participant holds a private exponent x; the
+// group key is the generator raised to all n exponents.
+//
+// The participant's first contribution is its public key. Every other
+// contribution is computed by Contribute, which raises an intermediate value
+// received from another participant to x. For each participant i, the other
+// n-1 participants in turn apply Contribute, starting from the public key of
+// one of them; participant i then applies Contribute to the result to obtain
+// the group key. This takes O(n^2) exponentiations in total. ComputeGroupKey
+// runs this construction for participants held in one process.
+//
+// Every intermediate value is validated with ValidatePublicKey, and its
+// exponentiation is blinded as in SharedKey.
+type GroupAgreement struct {
+	rand       io.Reader
+	privateKey []byte
+	publicKey  []byte
+}
+
+// NewGroupAgreement generates a new participant with a random private key.
+//
+// Random bytes are read from rand, which must be set to a CSPRNG, such as
+// crypto/rand.Reader, and are also used for blinding in Contribute. If rand
+// is nil, crypto/rand.Reader is used.
+func NewGroupAgreement(rand io.Reader) (*GroupAgreement, error) {
+	rand = randReader(rand)
+	publicKey, privateKey, err := GenerateKeyPair(rand)
+	if err != nil {
+		return nil, err
+	}
+	return &GroupAgreement{
+		rand:       rand,
+		privateKey: privateKey,
+		publicKey:  publicKey,
+	}, nil
+}
+
+// PublicKey returns a copy of the participant's public key, its first
+// contribution.
+func (a *GroupAgreement) PublicKey() []byte {
+	return append([]byte(nil), a.publicKey...)
+}
+
+// Contribute validates intermediate and returns it raised to the
+// participant's private exponent. Applied to the value that already contains
+// the contributions of all other participants, it returns the group key.
+func (a *GroupAgreement) Contribute(intermediate []byte) ([]byte, error) {
+	return SharedKey(a.rand, intermediate, a.privateKey)
+}
+
+// Close zeroizes the private key. Contribute must not be called after Close.
+func (a *GroupAgreement) Close() error {
+	Zeroize(a.privateKey)
+	return nil
+}
+
+// ComputeGroupKey runs the GroupAgreement construction for parties and
+// returns the group key. Each party computes the key from a value that
+// contains the contributions of all other parties; an error is returned if
+// the results differ.
+func ComputeGroupKey(parties []*GroupAgreement) ([]byte, error) {
+	n := len(parties)
+	if n < 2 {
+		return nil, ErrTooFewParties
+	}
+	var groupKey []byte
+	for i := range parties {
+		// Pass the public key of the next party through the others.
+		v := parties[(i+1)%n].PublicKey()
+		for j := 2; j < n; j++ {
+			next, err := parties[(i+j)%n].Contribute(v)
+			if err != nil {
+				Zeroize(groupKey)
+				return nil, err
+			}
+			v = next
+		}
+		key, err := parties[i].Contribute(v)
+		if err != nil {
+			Zeroize(groupKey)
+			return nil, err
+		}
+		if groupKey == nil {
+			groupKey = key
+			continue
+		}
+		equal := Equal(groupKey, key)
+		Zeroize(key)
+		if !equal {
+			Zeroize(groupKey)
+			return nil, errGroupKeyMismatch
+		}
+	}
+	return groupKey, nil
+}
@@ -0,0 +1,97 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestGroupAgreement(t *testing.T) {
+	parties := make([]*GroupAgreement, 3)
+	for i := range parties {
+		p, err := NewGroupAgreement(rand.Reader)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		defer p.Close()
+		parties[i] = p
+	}
+	a, b, c := parties[0], parties[1], parties[2]
+
+	// Each party computes the key from the contributions of the other two.
+	ab, err := b.Contribute(a.PublicKey())
+	if err != nil {
+		t.Fatalf("b: %s", err)
+	}
+	bc, err := c.Contribute(b.PublicKey())
+	if err != nil {
+		t.Fatalf("c: %s", err)
+	}
+	ca, err := a.Contribute(c.PublicKey())
+	if err != nil {
+		t.Fatalf("a: %s", err)
+	}
+	var keys [][]byte
+	for _, v := range []struct {
+		p            *GroupAgreement
+		intermediate []byte
+	}{{c, ab}, {a, bc}, {b, ca}} {
+		key, err := v.p.Contribute(v.intermediate)
+		if err != nil {
+			t.Fatalf("group key: %s", err)
+		}
+		keys = append(keys, key)
+	}
+	if !bytes.Equal(keys[0], keys[1]) || !bytes.Equal(keys[0], keys[2]) {
+		t.Fatalf("group keys are not equal")
+	}
+	// The group key differs from every pairwise shared key.
+	for _, v := range [][]byte{ab, bc, ca} {
+		if bytes.Equal(keys[0], v) {
+			t.Fatalf("group key equals pairwise key")
+		}
+	}
+
+	groupKey, err := ComputeGroupKey(parties)
+	if err != nil {
+		t.Fatalf("ComputeGroupKey: %s", err)
+	}
+	if !bytes.Equal(groupKey, keys[0]) {
+		t.Fatalf("ComputeGroupKey returned a different key")
+	}
+	if _, err := ComputeGroupKey(parties[:1]); err != ErrTooFewParties {
+		t.Fatalf("expected %v, got %v", ErrTooFewParties, err)
+	}
+	if _, err := a.Contribute(publicKeyBytes(one)); err != ErrDegeneratePublicKey {
+		t.Fatalf("expected %v, got %v", ErrDegeneratePublicKey, err)
+	}
+}
+
+func TestComputeGroupKeyContributeError(t *testing.T) {
+	a, err := NewGroupAgreement(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+	// b has enough randomness to generate its key pair, but not to blind
+	// its contribution, so the second party fails after the first one has
+	// computed the group key.
+	b, err := NewGroupAgreement(io.MultiReader(io.LimitReader(rand.Reader, 2*PrivateKeySize), errorReader{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	groupKey, err := ComputeGroupKey([]*GroupAgreement{a, b})
+	if !errors.Is(err, ErrRandomnessFailed) || groupKey != nil {
+		t.Fatalf("expected nil key and %v, got %x and %v", ErrRandomnessFailed, groupKey, err)
+	}
+}
@@ -0,0 +1,748 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Group is a Diffie-Hellman MODP group.
+//
+// Modulus must be a safe prime p = 2q + 1, and Generator must generate the
+// subgroup of prime order q, as is the case for RFC 3526 and RFC 7919 groups.
+// Public and shared keys are PublicKeySize bytes, which must be large enough
+// to hold the modulus, and private keys are PrivateKeySize bytes.
+//
+// A Group must not be modified after it has been used, and neither must the
+// values its fields point to. A Group is safe for concurrent use.
+type Group struct {
+	Modulus        *big.Int
+	Generator      *big.Int
+	PrivateKeySize int // private key size in bytes
+	PublicKeySize  int // public and shared key size in bytes
+
+	// ExponentOffset is added to private keys before exponentiation.
+	// If nil, 2^(8*PrivateKeySize + 2) is used, which is 2^258 for
+	// 32-byte private keys.
+	//
+	// Changing ExponentOffset changes every public and shared key, which
+	// breaks interoperability with spiped and with the package-level
+	// functions. Set it only for deployments that use a different offset.
+	ExponentOffset *big.Int
+
+	generatorOnce  sync.Once
+	generatorTable *fixedBaseTable
+
+	subgroupOrderOnce sync.Once
+	subgroupOrderQ    *big.Int
+
+	costOnce sync.Once
+	cost     time.Duration
+
+	modulusMinusOnce sync.Once
+	modulusMinusOneP *big.Int
+
+	// Default offsets for private key sizes other than PrivateKeySize,
+	// computed once so that the hot path doesn't allocate them.
+	offsetsOnce     sync.Once
+	exponentOffsetP *big.Int
+	blindingOffsetP *big.Int
+
+	name string // see GroupInfo
+	rfc  string
+}
+
+func newGroup(name, rfc string, modulus *big.Int) *Group {
+	return &Group{
+		name:           name,
+		rfc:            rfc,
+		Modulus:        modulus,
+		Generator:      generator,
+		PrivateKeySize: PrivateKeySize,
+		PublicKeySize:  (modulus.BitLen() + 7) / 8,
+	}
+}
+
+// ErrInvalidGroup is wrapped by errors returned by NewGroup for inconsistent
+// group parameters.
+var ErrInvalidGroup = errors.New("dhgroup14: invalid group parameters")
+
+// NewGroup returns a Group with the given modulus, generator and private key
+// size in bytes, after checking that they are consistent. The modulus must be
+// an odd number of at least 2048 bits, and the generator must generate a
+// subgroup of order (modulus-1)/2. The private key size must be large enough
+// for the modulus: exponents of at least twice as many bits as the security
+// strength of the modulus, as estimated by NIST SP 800-57, that is 28 bytes
+// for 2048-bit moduli, 32 bytes from 3072 bits, 48 bytes from 7680 bits and
+// 64 bytes from 15360 bits. It must also leave room for the exponent offset,
+// 2^(8*privateKeySize + 2), below the subgroup order.
+//
+// NewGroup does not check that the modulus is a safe prime, which is
+// expensive; use published parameters, such as those of RFC 3526 or RFC
+// 7919. modulus and generator are copied.
+func NewGroup(modulus, generator *big.Int, privateKeySize int) (*Group, error) {
+	bits := modulus.BitLen()
+	if bits < 2048 || modulus.Bit(0) == 0 {
+		return nil, fmt.Errorf("%w: modulus must be odd and at least 2048 bits", ErrInvalidGroup)
+	}
+	if min := minPrivateKeySize(bits); privateKeySize < min {
+		return nil, fmt.Errorf("%w: private key size %d is too small for a %d-bit modulus, need at least %d bytes", ErrInvalidGroup, privateKeySize, bits, min)
+	}
+	// Exponents are less than 2^(8*privateKeySize + 3), which must be
+	// less than the subgroup order.
+	if 8*privateKeySize+3 > bits-2 {
+		return nil, fmt.Errorf("%w: private key size %d is too large for a %d-bit modulus", ErrInvalidGroup, privateKeySize, bits)
+	}
+	g := &Group{
+		Modulus:        new(big.Int).Set(modulus),
+		Generator:      new(big.Int).Set(generator),
+		PrivateKeySize: privateKeySize,
+		PublicKeySize:  (bits + 7) / 8,
+	}
+	if generator.Cmp(one) <= 0 || generator.Cmp(g.modulusMinusOne()) >= 0 ||
+		new(big.Int).Exp(generator, g.subgroupOrder(), modulus).Cmp(one) != 0 {
+		return nil, fmt.Errorf("%w: generator does not generate the prime-order subgroup", ErrInvalidGroup)
+	}
+	return g, nil
+}
+
+// minPrivateKeySize returns the smallest private key size in bytes for a
+// modulus of the given bit length, which gives exponents of twice the
+// security strength of the modulus according to NIST SP 800-57 Part 1.
+func minPrivateKeySize(bits int) int {
+	switch {
+	case bits >= 15360:
+		return 64 // 256-bit strength
+	case bits >= 7680:
+		return 48 // 192-bit strength
+	case bits >= 3072:
+		return 32 // 128-bit strength
+	default:
+		return 28 // 112-bit strength
+	}
+}
+
+// randReader returns rand, or crypto/rand.Reader if rand is nil.
+func randReader(rand io.Reader) io.Reader {
+	if rand == nil {
+		return cryptorand.Reader
+	}
+	return rand
+}
+
+// Default offsets for PrivateKeySize-byte private keys, precomputed so that
+// they are added in a single step. Like modulus, they are shared by all
+// goroutines and must not be modified.
+var (
+	twoExp256 = new(big.Int).Lsh(one, 8*PrivateKeySize)
+	twoExp258 = new(big.Int).Lsh(one, 8*PrivateKeySize+2)
+)
+
+// exponentOffset returns ExponentOffset or its default. The result must not
+// be modified.
+func (g *Group) exponentOffset() *big.Int {
+	if g.ExponentOffset != nil {
+		return g.ExponentOffset
+	}
+	if g.PrivateKeySize == PrivateKeySize {
+		return twoExp258
+	}
+	g.computeOffsets()
+	return g.exponentOffsetP
+}
+
+// blindingOffset returns 2^(8*PrivateKeySize), which is added to random
+// blinding exponents. The result must not be modified.
+func (g *Group) blindingOffset() *big.Int {
+	if g.PrivateKeySize == PrivateKeySize {
+		return twoExp256
+	}
+	g.computeOffsets()
+	return g.blindingOffsetP
+}
+
+// computeOffsets computes the default offsets on the first call.
+func (g *Group) computeOffsets() {
+	g.offsetsOnce.Do(func() {
+		g.exponentOffsetP = new(big.Int).Lsh(one, uint(8*g.PrivateKeySize+2))
+		g.blindingOffsetP = new(big.Int).Lsh(one, uint(8*g.PrivateKeySize))
+	})
+}
+
+// expFunc sets z to base^e mod modulus for some base and returns z.
+type expFunc func(z, e *big.Int) *big.Int
+
+// baseExp returns expFunc for base a.
+func (g *Group) baseExp(a *big.Int) expFunc {
+	return func(z, e *big.Int) *big.Int {
+		return z.Exp(a, e, g.Modulus)
+	}
+}
+
+// generatorExp returns expFunc for the generator, which uses a fixed-base
+// table computed on the first call.
+func (g *Group) generatorExp() expFunc {
+	g.generatorOnce.Do(func() {
+		// Exponents are less than 2^(bits of offset + 1).
+		bits := g.exponentOffset().BitLen()
+		if bits < 8*g.PrivateKeySize+1 {
+			bits = 8*g.PrivateKeySize + 1
+		}
+		g.generatorTable = newFixedBaseTable(g.Generator, g.Modulus, bits+1)
+	})
+	return g.generatorTable.exp
+}
+
+// modulusMinusOne returns modulus-1, which must not be modified.
+func (g *Group) modulusMinusOne() *big.Int {
+	g.modulusMinusOnce.Do(func() {
+		g.modulusMinusOneP = new(big.Int).Sub(g.Modulus, one)
+	})
+	return g.modulusMinusOneP
+}
+
+// SubgroupOrder returns a copy of q = (modulus-1)/2, the order of the
+// prime-order subgroup generated by the generator.
+func (g *Group) SubgroupOrder() *big.Int {
+	return new(big.Int).Set(g.subgroupOrder())
+}
+
+// subgroupOrder returns (modulus-1)/2, computed once. The result must not be
+// modified.
+func (g *Group) subgroupOrder() *big.Int {
+	g.subgroupOrderOnce.Do(func() {
+		g.subgroupOrderQ = new(big.Int).Rsh(g.Modulus, 1)
+	})
+	return g.subgroupOrderQ
+}
+
+// OperationCost returns the measured wall-clock time of one SharedKey call
+// in group g, including public key validation and blinding, for capacity
+// planning. It is measured on the first call, which therefore takes a few
+// such operations: a public key is generated from a fixed private key, then
+// SharedKey is timed three times and the minimum is returned, to exclude
+// one-time setup and scheduling noise. Later calls return the cached value.
+// No measurement is done unless OperationCost is called.
+func (g *Group) OperationCost() time.Duration {
+	g.costOnce.Do(func() {
+		privateKey := make([]byte, g.PrivateKeySize)
+		copy(privateKey, selfTestVector.privateKey1)
+		publicKey, err := g.GeneratePublicKey(nil, privateKey)
+		if err != nil {
+			return
+		}
+		for i := 0; i < 3; i++ {
+			start := time.Now()
+			if _, err := g.SharedKey(nil, publicKey, privateKey); err != nil {
+				return
+			}
+			if d := time.Since(start); g.cost == 0 || d < g.cost {
+				g.cost = d
+			}
+		}
+	})
+	return g.cost
+}
+
+// GroupInfo describes a group for diagnostics, such as logging which
+// parameters a service uses.
+type GroupInfo struct {
+	Name          string // for example, "RFC 3526 MODP 2048 (group 14)"
+	ModulusBits   int    // bit length of the modulus
+	PublicKeySize int    // public and shared key size in bytes
+	RFC           string // for example, "RFC 3526"
+}
+
+// Info returns a description of group g. Name and RFC are empty for groups
+// not defined by this package.
+func (g *Group) Info() GroupInfo {
+	return GroupInfo{
+		Name:          g.name,
+		ModulusBits:   g.Modulus.BitLen(),
+		PublicKeySize: g.PublicKeySize,
+		RFC:           g.rfc,
+	}
+}
+
+// Fingerprint returns a short identifier of the parameters of group g: the
+// first 8 bytes of SHA-256 of the modulus followed by the generator, each
+// encoded as PublicKeySize big-endian bytes. Peers can exchange fingerprints
+// before a key exchange and abort if they differ, instead of computing
+// mismatched shared keys. Only the modulus and generator are covered, since
+// they alone determine whether two parties agree on a shared key.
+func (g *Group) Fingerprint() [8]byte {
+	h := sha256.New()
+	h.Write(g.Modulus.FillBytes(make([]byte, g.PublicKeySize)))
+	h.Write(g.Generator.FillBytes(make([]byte, g.PublicKeySize)))
+	var fp [8]byte
+	copy(fp[:], h.Sum(nil))
+	return fp
+}
+
+// GenerateKeyPair generates new random private key and the corresponding
+// public key in group g.
+//
+// Random bytes for the private key and for blinding are read from rand, which
+// must be set to a CSPRNG, such as crypto/rand.Reader. If rand is nil,
+// crypto/rand.Reader is used.
+func (g *Group) GenerateKeyPair(rand io.Reader) (publicKey, privateKey []byte, err error) {
+	return g.GenerateKeyPairContext(context.Background(), rand)
+}
+
+// GenerateKeyPairContext is like GenerateKeyPair, but returns ctx.Err()
+// if ctx is done before or during exponentiation.
+// A Tracer attached to ctx with WithTracer is notified of progress.
+func (g *Group) GenerateKeyPairContext(ctx context.Context, rand io.Reader) (publicKey, privateKey []byte, err error) {
+	rand = randReader(rand)
+	return g.generateKeyPair(ctx, rand, rand)
+}
+
+// GenerateKeyPairWithBlinding is like GenerateKeyPair, but reads the private
+// key from keyRand and random bytes for blinding from blindRand. Both must be
+// set to CSPRNGs: the timing-attack defense relies on blinding values being
+// unpredictable. If either is nil, crypto/rand.Reader is used in its place.
+func (g *Group) GenerateKeyPairWithBlinding(keyRand, blindRand io.Reader) (publicKey, privateKey []byte, err error) {
+	return g.generateKeyPair(context.Background(), randReader(keyRand), randReader(blindRand))
+}
+
+func (g *Group) generateKeyPair(ctx context.Context, keyRand, blindRand io.Reader) (publicKey, privateKey []byte, err error) {
+	checkRand(keyRand)
+	// Generate random private key.
+	privateKey = make([]byte, g.PrivateKeySize)
+	if _, err := io.ReadFull(keyRand, privateKey); err != nil {
+		return nil, nil, fmt.Errorf("%w: reading private key: %w", ErrRandomnessFailed, err)
+	}
+	// A private key of all zero or all 0xff bytes is generated with
+	// negligible probability by a working source, but is what a broken one,
+	// or an unseeded or unprogrammed device, tends to return.
+	if err := g.checkPrivateKey(privateKey); err != nil {
+		Zeroize(privateKey)
+		return nil, nil, fmt.Errorf("%w: generated private key is weak, the source may be broken: %w", ErrRandomnessFailed, err)
+	}
+	tracer := tracerFromContext(ctx)
+	tracer.OnPrivateKeyGenerated(len(privateKey))
+	// Create public key: compute generator^(ExponentOffset + privateKey)
+	start := time.Now()
+	publicKey, err = g.blindedModExp(ctx, blindRand, g.generatorExp(), privateKey)
+	tracer.OnPublicKeyComputed(len(publicKey), time.Since(start), err)
+	if err != nil {
+		Zeroize(privateKey)
+		return nil, nil, err
+	}
+	return
+}
+
+// GeneratePublicKey returns a public key corresponding to the given private
+// key (generator^(ExponentOffset + privateKey) in group g).
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func (g *Group) GeneratePublicKey(rand io.Reader, privateKey []byte) (publicKey []byte, err error) {
+	if err := g.checkPrivateKey(privateKey); err != nil {
+		return nil, err
+	}
+	// Create public key: compute generator^(ExponentOffset + privateKey)
+	return g.blindedModExp(context.Background(), randReader(rand), g.generatorExp(), privateKey)
+}
+
+// GeneratePublicKeyDeterministic is like GeneratePublicKey, but performs
+// exponentiation without blinding and does not need randomness.
+//
+// WARNING: this function is not resistant to timing attacks. It is intended
+// only for verification and test vector generation. Use GeneratePublicKey
+// for everything else.
+func (g *Group) GeneratePublicKeyDeterministic(privateKey []byte) (publicKey []byte, err error) {
+	if err := g.checkPrivateKey(privateKey); err != nil {
+		return nil, err
+	}
+	return g.modExp(g.generatorExp(), privateKey)
+}
+
+// VerifyKeyPair reports whether publicKey corresponds to privateKey in
+// group g. It recomputes the public key with GeneratePublicKeyDeterministic
+// and compares it with publicKey in constant time, so it does not need
+// randomness. It returns an error if either key has the wrong size or
+// privateKey is weak.
+//
+// WARNING: like GeneratePublicKeyDeterministic, this function is not
+// resistant to timing attacks on privateKey.
+func (g *Group) VerifyKeyPair(publicKey, privateKey []byte) (bool, error) {
+	if len(publicKey) != g.PublicKeySize {
+		return false, ErrWrongPublicKeySize
+	}
+	expected, err := g.GeneratePublicKeyDeterministic(privateKey)
+	if err != nil {
+		return false, err
+	}
+	defer Zeroize(expected)
+	return Equal(expected, publicKey), nil
+}
+
+// SharedKey returns a shared key between theirPublicKey and myPrivateKey
+// (theirPublicKey^(ExponentOffset + myPrivateKey) in group g.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func (g *Group) SharedKey(rand io.Reader, theirPublicKey, myPrivateKey []byte) (sharedKey []byte, err error) {
+	return g.SharedKeyContext(context.Background(), rand, theirPublicKey, myPrivateKey)
+}
+
+// SharedKeyContext is like SharedKey, but returns ctx.Err() if ctx is done
+// before or during exponentiation.
+// A Tracer attached to ctx with WithTracer is notified of progress.
+func (g *Group) SharedKeyContext(ctx context.Context, rand io.Reader, theirPublicKey, myPrivateKey []byte) (sharedKey []byte, err error) {
+	sharedKey = make([]byte, g.PublicKeySize)
+	if err := g.sharedKeyInto(ctx, sharedKey, rand, theirPublicKey, myPrivateKey); err != nil {
+		return nil, err
+	}
+	return sharedKey, nil
+}
+
+// SharedKeyInto is like SharedKey, but writes the shared key into dst, which
+// must be PublicKeySize bytes long, instead of allocating it. See the
+// package-level SharedKeyInto for details.
+func (g *Group) SharedKeyInto(dst []byte, rand io.Reader, theirPublicKey, myPrivateKey []byte) error {
+	if len(dst) != g.PublicKeySize {
+		return ErrWrongSharedKeySize
+	}
+	return g.sharedKeyInto(context.Background(), dst, rand, theirPublicKey, myPrivateKey)
+}
+
+// sharedKeyInto computes the shared key into dst, which must be
+// PublicKeySize bytes long. dst is only written on success.
+func (g *Group) sharedKeyInto(ctx context.Context, dst []byte, rand io.Reader, theirPublicKey, myPrivateKey []byte) error {
+	if len(theirPublicKey) != g.PublicKeySize {
+		return ErrWrongPublicKeySize
+	}
+	if err := g.checkPrivateKey(myPrivateKey); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	bp := new(big.Int).SetBytes(theirPublicKey)
+	if err := g.validatePublicKey(bp); err != nil {
+		return err
+	}
+	// Calculate shared key.
+	start := time.Now()
+	err := g.blindedModExpInto(ctx, dst, randReader(rand), g.baseExp(bp), myPrivateKey)
+	size := len(dst)
+	if err != nil {
+		size = 0
+	}
+	tracerFromContext(ctx).OnSharedKeyComputed(size, time.Since(start), err)
+	return err
+}
+
+// SharedKeyUnblinded is like SharedKey, but performs exponentiation without
+// blinding and does not need randomness.
+//
+// WARNING: this function is not resistant to timing attacks. It is intended
+// only for benchmarks and test vector cross-checking. Use SharedKey for
+// everything else.
+func (g *Group) SharedKeyUnblinded(theirPublicKey, myPrivateKey []byte) (sharedKey []byte, err error) {
+	if len(theirPublicKey) != g.PublicKeySize {
+		return nil, ErrWrongPublicKeySize
+	}
+	if err := g.checkPrivateKey(myPrivateKey); err != nil {
+		return nil, err
+	}
+	bp := new(big.Int).SetBytes(theirPublicKey)
+	if err := g.validatePublicKey(bp); err != nil {
+		return nil, err
+	}
+	return g.modExp(g.baseExp(bp), myPrivateKey)
+}
+
+// IsValidPrivateKey reports whether privateKey would be accepted by
+// GeneratePublicKey and SharedKey in group g. It runs in time that depends
+// only on the length of privateKey.
+func (g *Group) IsValidPrivateKey(privateKey []byte) bool {
+	return g.checkPrivateKey(privateKey) == nil
+}
+
+// checkPrivateKey checks that privateKey has the correct size and is not
+// all zeros or all ones.
+func (g *Group) checkPrivateKey(privateKey []byte) error {
+	if len(privateKey) != g.PrivateKeySize {
+		return ErrWrongPrivateKeySize
+	}
+	// Don't exit early to avoid leaking private key bytes.
+	or, and := byte(0), byte(0xff)
+	for _, b := range privateKey {
+		or |= b
+		and &= b
+	}
+	if or == 0 || and == 0xff {
+		return ErrWeakPrivateKey
+	}
+	return nil
+}
+
+// ValidatePublicKey checks that publicKey is a valid public key in group g.
+// See the package-level ValidatePublicKey for details.
+func (g *Group) ValidatePublicKey(publicKey []byte) error {
+	if len(publicKey) != g.PublicKeySize {
+		return ErrWrongPublicKeySize
+	}
+	return g.validatePublicKey(new(big.Int).SetBytes(publicKey))
+}
+
+// ValidatePublicKeyStrict is like ValidatePublicKey, but additionally
+// rejects public keys equal to Generator^k mod Modulus for
+// 0 <= k < 8*PublicKeySize. See the package-level ValidatePublicKeyStrict.
+func (g *Group) ValidatePublicKeyStrict(publicKey []byte) error {
+	if err := g.ValidatePublicKey(publicKey); err != nil {
+		return err
+	}
+	y := new(big.Int).SetBytes(publicKey)
+	v := new(big.Int).Set(one)
+	for k := 0; k < 8*g.PublicKeySize; k++ {
+		if y.Cmp(v) == 0 {
+			return ErrSmallPowerPublicKey
+		}
+		v.Mul(v, g.Generator)
+		v.Mod(v, g.Modulus)
+	}
+	return nil
+}
+
+// ValidatePublicKeyFast performs the cheap checks of ValidatePublicKey in
+// group g, without the subgroup check. See the package-level
+// ValidatePublicKeyFast for details.
+func (g *Group) ValidatePublicKeyFast(publicKey []byte) error {
+	if len(publicKey) != g.PublicKeySize {
+		return ErrWrongPublicKeySize
+	}
+	return g.validatePublicKeyFast(new(big.Int).SetBytes(publicKey))
+}
+
+// ValidatePublicKeySubgroup performs only the subgroup check of
+// ValidatePublicKey in group g. See the package-level
+// ValidatePublicKeySubgroup for details.
+func (g *Group) ValidatePublicKeySubgroup(publicKey []byte) error {
+	if len(publicKey) != g.PublicKeySize {
+		return ErrWrongPublicKeySize
+	}
+	y := new(big.Int).SetBytes(publicKey)
+	if err := g.checkPublicKeyRange(y); err != nil {
+		return err
+	}
+	return g.validatePublicKeySubgroup(y)
+}
+
+func (g *Group) validatePublicKey(y *big.Int) error {
+	if err := g.validatePublicKeyFast(y); err != nil {
+		return err
+	}
+	return g.validatePublicKeySubgroup(y)
+}
+
+func (g *Group) validatePublicKeyFast(y *big.Int) error {
+	// The checks here and in validatePublicKeySubgroup are not
+	// constant-time. A constant-time comparison would be feasible on the
+	// encoded bytes, but not for the rest of validation: math/big's Cmp,
+	// BitLen and Exp all take time that depends on their operands. This
+	// is acceptable because the public key is not secret: it is sent in
+	// the clear, so timing reveals nothing the peer or an eavesdropper
+	// doesn't already know. Only the private key exponent needs
+	// protection, which blinding provides.
+	if err := g.checkPublicKeyRange(y); err != nil {
+		return err
+	}
+	// Reject 0, 1 and modulus-1, which generate trivial subgroups.
+	if y.Cmp(one) < 1 || y.Cmp(g.modulusMinusOne()) == 0 {
+		return ErrDegeneratePublicKey
+	}
+	// Cheaply reject values shorter than half of the modulus: an honestly
+	// generated public key is that short with negligible probability
+	// (about 2^-1024 for group #14).
+	if y.BitLen() < g.Modulus.BitLen()/2 {
+		return ErrPublicKeyTooSmall
+	}
+	// Fast partial subgroup check: elements of the prime-order subgroup of
+	// a safe prime group are quadratic residues. Non-residues are half of
+	// all values, so this rejects them without an exponentiation.
+	if big.Jacobi(y, g.Modulus) != 1 {
+		return ErrPublicKeyNotInSubgroup
+	}
+	return nil
+}
+
+// checkPublicKeyRange returns ErrPublicKeyOutOfRange unless 0 <= y < modulus.
+// Public keys decoded from bytes are never negative, but y may come from
+// elsewhere, and Exp would happily reduce a negative value into range.
+func (g *Group) checkPublicKeyRange(y *big.Int) error {
+	if y.Sign() < 0 || y.Cmp(g.Modulus) >= 0 {
+		return ErrPublicKeyOutOfRange
+	}
+	return nil
+}
+
+// validatePublicKeySubgroup checks that y, which must be less than the
+// modulus, is in the prime-order subgroup.
+func (g *Group) validatePublicKeySubgroup(y *big.Int) error {
+	if new(big.Int).Exp(y, g.subgroupOrder(), g.Modulus).Cmp(one) != 0 {
+		return ErrPublicKeyNotInSubgroup
+	}
+	return nil
+}
+
+// parallelExp reports whether blindedModExp runs its two exponentiations
+// concurrently.
+var parallelExp = runtime.NumCPU() > 1
+
+// blindedModExp returns base^(ExponentOffset + privateKey) mod modulus
+// computed by exp with blinding.
+//
+// Note on timing: math/big does not provide constant-time arithmetic. For
+// odd moduli and multi-word positive exponents, which is always the case
+// here, big.Int.Exp uses Montgomery multiplication with a fixed 4-bit
+// window, so its sequence of operations does not depend on exponent bits,
+// but the underlying multiplications are still not guaranteed to be
+// constant-time. Blinding is the defense: the exponents passed to exp are
+// a fresh random value and ExponentOffset + privateKey minus that value,
+// so timing of a single operation reveals nothing useful about privateKey.
+//
+// It returns ctx.Err() if ctx is done before exponentiation, or during it:
+// between the exponentiations if they are sequential, or after both finish
+// if they are concurrent.
+func (g *Group) blindedModExp(ctx context.Context, rand io.Reader, exp expFunc, privateKey []byte) ([]byte, error) {
+	result := make([]byte, g.PublicKeySize)
+	if err := g.blindedModExpInto(ctx, result, rand, exp, privateKey); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// blindedModExpInto is like blindedModExp, but encodes the result into dst,
+// which must be PublicKeySize bytes long. dst is only written on success.
+func (g *Group) blindedModExpInto(ctx context.Context, dst []byte, rand io.Reader, exp expFunc, privateKey []byte) error {
+	// Calculate ExponentOffset + privateKey
+	priv := getInt().SetBytes(privateKey)
+	defer putInt(priv)
+	priv.Add(priv, g.exponentOffset())
+
+	r := getInt()
+	defer putInt(r)
+	if err := g.blindedExp(ctx, rand, exp, r, priv); err != nil {
+		return err
+	}
+	return g.encodeResultInto(dst, r)
+}
+
+// blindedExp sets z to exp(e) computed with blinding, as described in
+// blindedModExp. The value of e is destroyed. If e is smaller than the
+// blinding value, modulus-1 is added to the blinded exponent, which does
+// not change the result for bases coprime to the modulus.
+func (g *Group) blindedExp(ctx context.Context, rand io.Reader, exp expFunc, z, e *big.Int) error {
+	// Generate random blinding exponent.
+	blindingBytes := make([]byte, g.PrivateKeySize)
+	defer Zeroize(blindingBytes)
+	if _, err := io.ReadFull(rand, blindingBytes); err != nil {
+		return fmt.Errorf("%w: reading blinding: %w", ErrRandomnessFailed, err)
+	}
+	checkBlinding(blindingBytes)
+	blinding := getInt().SetBytes(blindingBytes)
+	defer putInt(blinding)
+	blinding.Add(blinding, g.blindingOffset())
+
+	// Calculate blinded exponent.
+	eBlinded := e.Sub(e, blinding)
+	if eBlinded.Sign() < 0 {
+		eBlinded.Add(eBlinded, g.modulusMinusOne())
+	}
+
+	// Exponentiate mod modulus.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r := getInt()
+	defer putInt(r)
+	if parallelExp {
+		// The exponentiations are independent and only read the
+		// base and modulus, so they can run concurrently.
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			exp(z, blinding)
+		}()
+		exp(r, eBlinded)
+		wg.Wait()
+		// ctx cannot interrupt the concurrent exponentiations, so
+		// report cancellation once they finish.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	} else {
+		exp(z, blinding)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		exp(r, eBlinded)
+	}
+
+	// Calculate result: (z * r) mod modulus. The product is positive, so
+	// QuoRem gives the same remainder as Mod, but uses a pooled quotient
+	// instead of allocating one.
+	z.Mul(z, r)
+	q := getInt()
+	defer putInt(q)
+	q.QuoRem(z, g.Modulus, z)
+	return nil
+}
+
+// modExp is like blindedModExp, but without blinding.
+func (g *Group) modExp(exp expFunc, privateKey []byte) ([]byte, error) {
+	// Calculate ExponentOffset + privateKey
+	priv := getInt().SetBytes(privateKey)
+	defer putInt(priv)
+	priv.Add(priv, g.exponentOffset())
+
+	r := exp(getInt(), priv)
+	defer putInt(r)
+
+	return g.encodeResult(r)
+}
+
+// encodeResult checks the result r of exponentiation and returns it as
+// a PublicKeySize-byte big-endian value, left-padded with zero bytes.
+// Results are never truncated: a result that does not fit is rejected.
+func (g *Group) encodeResult(r *big.Int) ([]byte, error) {
+	result := make([]byte, g.PublicKeySize)
+	if err := g.encodeResultInto(result, r); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// encodeResultInto is like encodeResult, but encodes r into dst, which must
+// be PublicKeySize bytes long.
+func (g *Group) encodeResultInto(dst []byte, r *big.Int) error {
+	// Reject 0, 1 and modulus-1, which a crafted base may force.
+	if r.Cmp(one) < 1 || r.Cmp(g.modulusMinusOne()) == 0 {
+		return ErrDegenerateResult
+	}
+
+	if r.BitLen() > 8*len(dst) {
+		return ErrResultTooLarge
+	}
+	r.FillBytes(dst)
+	return nil
+}
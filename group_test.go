@@ -0,0 +1,564 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+var groups = []struct {
+	name    string
+	group   *Group
+	bits    int
+	keySize int
+}{
+	{"group14", Group14, 2048, 256},
+	{"group15", Group15, 3072, 384},
+	{"group16", Group16, 4096, 512},
+	{"ffdhe2048", FFDHE2048, 2048, 256},
+}
+
+// allGroups returns groups and Group18, which is left out of groups
+// because its operations are slow.
+func allGroups() []struct {
+	name    string
+	group   *Group
+	bits    int
+	keySize int
+} {
+	return append(groups[:len(groups):len(groups)], struct {
+		name    string
+		group   *Group
+		bits    int
+		keySize int
+	}{"group18", Group18, 8192, 1024})
+}
+
+func TestGroupParameters(t *testing.T) {
+	for _, v := range groups {
+		if v.group.Modulus.BitLen() != v.bits {
+			t.Errorf("%s: modulus is %d bits, expected %d", v.name, v.group.Modulus.BitLen(), v.bits)
+		}
+		if v.group.PublicKeySize != v.keySize {
+			t.Errorf("%s: key size is %d, expected %d", v.name, v.group.PublicKeySize, v.keySize)
+		}
+		// Modulus must be a safe prime.
+		if !v.group.Modulus.ProbablyPrime(20) || !v.group.subgroupOrder().ProbablyPrime(20) {
+			t.Errorf("%s: modulus is not a safe prime", v.name)
+		}
+	}
+}
+
+func TestGroupKeyExchange(t *testing.T) {
+	for _, v := range groups {
+		publicKey1, privateKey1, err := v.group.GenerateKeyPair(rand.Reader)
+		if err != nil {
+			t.Fatalf("%s: generate key pair 1: %s", v.name, err)
+		}
+		publicKey2, privateKey2, err := v.group.GenerateKeyPair(rand.Reader)
+		if err != nil {
+			t.Fatalf("%s: generate key pair 2: %s", v.name, err)
+		}
+		if len(publicKey1) != v.keySize {
+			t.Fatalf("%s: public key is %d bytes, expected %d", v.name, len(publicKey1), v.keySize)
+		}
+		if err := v.group.ValidatePublicKey(publicKey1); err != nil {
+			t.Fatalf("%s: generated public key is invalid: %s", v.name, err)
+		}
+		sharedKey1, err := v.group.SharedKey(rand.Reader, publicKey1, privateKey2)
+		if err != nil {
+			t.Fatalf("%s: compute shared key 1: %s", v.name, err)
+		}
+		sharedKey2, err := v.group.SharedKey(rand.Reader, publicKey2, privateKey1)
+		if err != nil {
+			t.Fatalf("%s: compute shared key 2: %s", v.name, err)
+		}
+		if !bytes.Equal(sharedKey1, sharedKey2) {
+			t.Fatalf("%s: two shared keys are not equal!", v.name)
+		}
+	}
+}
+
+func TestGroupRejectsForeignPublicKey(t *testing.T) {
+	if _, err := Group15.SharedKey(rand.Reader, golden.publicKey1, golden.privateKey2); err == nil {
+		t.Fatalf("group15 accepted group14 public key")
+	}
+	big15 := make([]byte, 384)
+	big15[0] = 1
+	if _, err := Group14.SharedKey(rand.Reader, big15, golden.privateKey2); err == nil {
+		t.Fatalf("group14 accepted group15-sized public key")
+	}
+}
+
+func TestGroup16KnownAnswer(t *testing.T) {
+	// SHA-256 of the group #16 shared key between golden.privateKey1 and
+	// golden.privateKey2, computed independently.
+	const expected = "42caa15911063e019c1b7140dec100e04f0b7d7aa50dc0d4b1bfd8b50cf36230"
+	publicKey1, err := Group16.GeneratePublicKey(rand.Reader, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("generate public key 1: %s", err)
+	}
+	publicKey2, err := Group16.GeneratePublicKey(rand.Reader, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("generate public key 2: %s", err)
+	}
+	sharedKey1, err := Group16.SharedKey(rand.Reader, publicKey1, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("compute shared key 1: %s", err)
+	}
+	sharedKey2, err := Group16.SharedKey(rand.Reader, publicKey2, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("compute shared key 2: %s", err)
+	}
+	if !bytes.Equal(sharedKey1, sharedKey2) {
+		t.Fatalf("two shared keys are not equal!")
+	}
+	h := sha256.Sum256(sharedKey1)
+	if got := hex.EncodeToString(h[:]); got != expected {
+		t.Fatalf("expected shared key hash %s, got %s", expected, got)
+	}
+}
+
+func TestCustomGroup(t *testing.T) {
+	// Group with group #14 parameters must be compatible with Group14.
+	g := &Group{
+		Modulus:        new(big.Int).Set(modulus),
+		Generator:      big.NewInt(2),
+		PrivateKeySize: 32,
+		PublicKeySize:  256,
+	}
+	sharedKey, err := g.SharedKey(rand.Reader, golden.publicKey1, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("compute shared key: %s", err)
+	}
+	if !bytes.Equal(sharedKey, golden.sharedKey) {
+		t.Fatalf(`expecting "%x", got "%x"`, golden.sharedKey, sharedKey)
+	}
+
+	// Group with larger private keys.
+	g = &Group{
+		Modulus:        Group16.Modulus,
+		Generator:      Group16.Generator,
+		PrivateKeySize: 64,
+		PublicKeySize:  512,
+	}
+	publicKey1, privateKey1, err := g.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key pair 1: %s", err)
+	}
+	publicKey2, privateKey2, err := g.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key pair 2: %s", err)
+	}
+	if len(privateKey1) != 64 {
+		t.Fatalf("private key is %d bytes, expected 64", len(privateKey1))
+	}
+	sharedKey1, err := g.SharedKey(rand.Reader, publicKey1, privateKey2)
+	if err != nil {
+		t.Fatalf("compute shared key 1: %s", err)
+	}
+	sharedKey2, err := g.SharedKey(rand.Reader, publicKey2, privateKey1)
+	if err != nil {
+		t.Fatalf("compute shared key 2: %s", err)
+	}
+	if !bytes.Equal(sharedKey1, sharedKey2) {
+		t.Fatalf("two shared keys are not equal!")
+	}
+}
+
+func benchmarkBlindedModExp(b *testing.B, parallel bool) {
+	defer func(p bool) { parallelExp = p }(parallelExp)
+	parallelExp = parallel
+	a := new(big.Int).SetBytes(golden.publicKey1)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Group14.blindedModExp(ctx, rand.Reader, Group14.baseExp(a), golden.privateKey2); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBlindedModExpSequential(b *testing.B) { benchmarkBlindedModExp(b, false) }
+func BenchmarkBlindedModExpParallel(b *testing.B)   { benchmarkBlindedModExp(b, true) }
+
+// benchmarkBlindedModExpOverhead measures the work blindedModExp does around
+// the exponentiations, which are replaced with a copy of the base.
+func benchmarkBlindedModExpOverhead(b *testing.B, g *Group) {
+	defer func(p bool) { parallelExp = p }(parallelExp)
+	parallelExp = false
+	a := new(big.Int).Lsh(one, uint(8*g.PublicKeySize-8))
+	exp := func(z, e *big.Int) *big.Int { return z.Set(a) }
+	privateKey := make([]byte, g.PrivateKeySize)
+	copy(privateKey, golden.privateKey2)
+	dst := make([]byte, g.PublicKeySize)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := g.blindedModExpInto(ctx, dst, rand.Reader, exp, privateKey); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBlindedModExpOverhead(b *testing.B)        { benchmarkBlindedModExpOverhead(b, Group14) }
+func BenchmarkBlindedModExpOverheadGroup18(b *testing.B) { benchmarkBlindedModExpOverhead(b, Group18) }
+
+// TestMontgomeryExpConditions checks that exponentiations satisfy the
+// conditions for big.Int.Exp to use its regular Montgomery path: odd
+// modulus and positive exponents longer than one word.
+func TestMontgomeryExpConditions(t *testing.T) {
+	for _, v := range groups {
+		if v.group.Modulus.Bit(0) != 1 {
+			t.Errorf("%s: modulus is even", v.name)
+		}
+		// The smallest exponents are blinding values, which are at
+		// least 2^(8*PrivateKeySize), and blinded private keys, which
+		// are at least ExponentOffset - 2^(8*PrivateKeySize+1).
+		minExp := new(big.Int).Sub(v.group.exponentOffset(), new(big.Int).Lsh(v.group.blindingOffset(), 1))
+		if v.group.blindingOffset().Cmp(minExp) < 0 {
+			minExp = v.group.blindingOffset()
+		}
+		if minExp.Sign() <= 0 || len(minExp.Bits()) < 2 {
+			t.Errorf("%s: exponents may be shorter than two words", v.name)
+		}
+	}
+}
+
+func TestFFDHE2048(t *testing.T) {
+	if FFDHE2048.Modulus.Cmp(Group14.Modulus) == 0 {
+		t.Fatalf("ffdhe2048 modulus equals group 14 modulus")
+	}
+	// Group 14 public keys are not valid in ffdhe2048.
+	if err := FFDHE2048.ValidatePublicKey(golden.publicKey1); err == nil {
+		t.Fatalf("ffdhe2048 accepted group 14 public key")
+	}
+}
+
+func TestExponentOffset(t *testing.T) {
+	g := newGroup("", "", Group14.Modulus)
+	g.ExponentOffset = new(big.Int).Lsh(one, 258)
+	publicKey, err := g.GeneratePublicKey(rand.Reader, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("generating public key: %s", err)
+	}
+	if !bytes.Equal(publicKey, golden.publicKey1) {
+		t.Fatalf("explicit default offset: wrong public key")
+	}
+	sharedKey, err := g.SharedKey(rand.Reader, golden.publicKey2, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("compute shared key: %s", err)
+	}
+	if !bytes.Equal(sharedKey, golden.sharedKey) {
+		t.Fatalf("explicit default offset: wrong shared key")
+	}
+
+	g = newGroup("", "", Group14.Modulus)
+	g.ExponentOffset = new(big.Int).Lsh(one, 259)
+	publicKey1, err := g.GeneratePublicKey(rand.Reader, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("generating public key 1: %s", err)
+	}
+	if bytes.Equal(publicKey1, golden.publicKey1) {
+		t.Fatalf("custom offset: public key did not change")
+	}
+	publicKey2, err := g.GeneratePublicKey(rand.Reader, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("generating public key 2: %s", err)
+	}
+	sharedKey1, err := g.SharedKey(rand.Reader, publicKey1, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("compute shared key 1: %s", err)
+	}
+	sharedKey2, err := g.SharedKey(rand.Reader, publicKey2, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("compute shared key 2: %s", err)
+	}
+	if !bytes.Equal(sharedKey1, sharedKey2) {
+		t.Fatalf("custom offset: shared keys differ")
+	}
+}
+
+func TestPrecomputedOffsets(t *testing.T) {
+	// spiped adds 2^256 four times to get 2^258.
+	twoExp256 := new(big.Int).Lsh(one, 256)
+	offset := new(big.Int)
+	for i := 0; i < 4; i++ {
+		offset.Add(offset, twoExp256)
+	}
+	if Group14.exponentOffset().Cmp(offset) != 0 {
+		t.Fatalf("exponent offset is not 4*2^256")
+	}
+	if Group14.blindingOffset().Cmp(twoExp256) != 0 {
+		t.Fatalf("blinding offset is not 2^256")
+	}
+	e := new(big.Int).Add(new(big.Int).SetBytes(golden.privateKey1), offset)
+	expected := publicKeyBytes(new(big.Int).Exp(Generator(), e, Modulus()))
+	publicKey, err := GeneratePublicKey(rand.Reader, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("generating public key: %s", err)
+	}
+	if !bytes.Equal(publicKey, expected) {
+		t.Fatalf("public key differs from four-add computation")
+	}
+	// Groups with other private key sizes compute their offsets.
+	g := &Group{PrivateKeySize: 16}
+	if g.exponentOffset().Cmp(new(big.Int).Lsh(one, 130)) != 0 || g.blindingOffset().Cmp(new(big.Int).Lsh(one, 128)) != 0 {
+		t.Fatalf("wrong offsets for 16-byte private keys")
+	}
+}
+
+func TestGroup18(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow group #18 test in short mode")
+	}
+	g := Group18
+	if g.Modulus.BitLen() != 8192 || g.PublicKeySize != 1024 {
+		t.Fatalf("wrong parameters: %d-bit modulus, %d-byte keys", g.Modulus.BitLen(), g.PublicKeySize)
+	}
+	if !g.Modulus.ProbablyPrime(1) || !g.subgroupOrder().ProbablyPrime(1) {
+		t.Fatalf("modulus is not a safe prime")
+	}
+	if g.PrivateKeySize != 48 {
+		t.Fatalf("wrong private key size %d", g.PrivateKeySize)
+	}
+	// 48-byte private keys made of both golden private keys.
+	privateKey1 := append(append([]byte(nil), golden.privateKey1...), golden.privateKey2[:16]...)
+	privateKey2 := append(append([]byte(nil), golden.privateKey2...), golden.privateKey1[:16]...)
+	// SHA-256 of the group #18 shared key between privateKey1 and
+	// privateKey2, computed independently.
+	const expected = "10b71bb763d4987a32e13338443059e8dac739e758941516d2d744c3c8fd583e"
+	ctx := context.Background()
+	publicKey1, err := g.GeneratePublicKey(rand.Reader, privateKey1)
+	if err != nil {
+		t.Fatalf("generate public key 1: %s", err)
+	}
+	publicKey3, privateKey3, err := g.GenerateKeyPairContext(ctx, rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key pair 3: %s", err)
+	}
+	sharedKey1, err := g.SharedKeyContext(ctx, rand.Reader, publicKey1, privateKey3)
+	if err != nil {
+		t.Fatalf("compute shared key 1: %s", err)
+	}
+	sharedKey2, err := g.SharedKeyContext(ctx, rand.Reader, publicKey3, privateKey1)
+	if err != nil {
+		t.Fatalf("compute shared key 2: %s", err)
+	}
+	if len(sharedKey1) != 1024 || !bytes.Equal(sharedKey1, sharedKey2) {
+		t.Fatalf("two shared keys are not equal!")
+	}
+	sharedKey, err := g.SharedKeyUnblinded(publicKey1, privateKey2)
+	if err != nil {
+		t.Fatalf("compute known-answer shared key: %s", err)
+	}
+	h := sha256.Sum256(sharedKey)
+	if got := hex.EncodeToString(h[:]); got != expected {
+		t.Fatalf("expected shared key hash %s, got %s", expected, got)
+	}
+}
+
+func TestNewGroup(t *testing.T) {
+	g, err := NewGroup(Group14.Modulus, big.NewInt(2), PrivateKeySize)
+	if err != nil {
+		t.Fatalf("group #14: %s", err)
+	}
+	publicKey, err := g.GeneratePublicKey(rand.Reader, golden.privateKey1)
+	if err != nil || !bytes.Equal(publicKey, golden.publicKey1) {
+		t.Fatalf("group #14 public key doesn't match: %v", err)
+	}
+	// Built-in groups are consistent.
+	for _, v := range allGroups() {
+		if _, err := NewGroup(v.group.Modulus, v.group.Generator, v.group.PrivateKeySize); err != nil {
+			t.Errorf("%s: %s", v.name, err)
+		}
+	}
+	bad := []struct {
+		name           string
+		modulus        *big.Int
+		generator      *big.Int
+		privateKeySize int
+	}{
+		{"small key for group #14", Group14.Modulus, big.NewInt(2), 16},
+		{"32-byte key for group #18", Group18.Modulus, big.NewInt(2), 32},
+		{"huge key", Group14.Modulus, big.NewInt(2), 256},
+		{"small modulus", new(big.Int).Rsh(Group14.Modulus, 1024), big.NewInt(2), 32},
+		{"even modulus", new(big.Int).Sub(Group14.Modulus, one), big.NewInt(2), 32},
+		{"generator 1", Group14.Modulus, big.NewInt(1), 32},
+		{"generator modulus-1", Group14.Modulus, new(big.Int).Sub(Group14.Modulus, one), 32},
+		{"non-residue generator", Group14.Modulus, new(big.Int).Sub(Group14.Modulus, big.NewInt(2)), 32},
+	}
+	for _, v := range bad {
+		if _, err := NewGroup(v.modulus, v.generator, v.privateKeySize); !errors.Is(err, ErrInvalidGroup) {
+			t.Errorf("%s: expected %v, got %v", v.name, ErrInvalidGroup, err)
+		}
+	}
+	// Parameters are copied.
+	m := new(big.Int).Set(Group14.Modulus)
+	g, err = NewGroup(m, big.NewInt(2), PrivateKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetInt64(0)
+	if g.Modulus.Cmp(Group14.Modulus) != 0 {
+		t.Fatalf("modulus not copied")
+	}
+}
+
+func TestDefaultGroup(t *testing.T) {
+	defer func(g *Group) { DefaultGroup = g }(DefaultGroup)
+	DefaultGroup = Group15
+
+	publicKey1, privateKey1, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key pair 1: %s", err)
+	}
+	publicKey2, privateKey2, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key pair 2: %s", err)
+	}
+	if len(publicKey1) != 384 {
+		t.Fatalf("public key is %d bytes, expected 384", len(publicKey1))
+	}
+	sharedKey1, err := SharedKey(rand.Reader, publicKey1, privateKey2)
+	if err != nil {
+		t.Fatalf("compute shared key 1: %s", err)
+	}
+	sharedKey2, err := SharedKey(rand.Reader, publicKey2, privateKey1)
+	if err != nil {
+		t.Fatalf("compute shared key 2: %s", err)
+	}
+	if len(sharedKey1) != 384 || !bytes.Equal(sharedKey1, sharedKey2) {
+		t.Fatalf("shared keys are not equal 384-byte values")
+	}
+	if _, err := SharedKey(rand.Reader, golden.publicKey1, golden.privateKey2); err != ErrWrongPublicKeySize {
+		t.Fatalf("group 14 key: expected %v, got %v", ErrWrongPublicKeySize, err)
+	}
+	// Typed keys always use group #14.
+	typedPublic, _, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate typed key: %s", err)
+	}
+	if err := Group14.ValidatePublicKey(typedPublic[:]); err != nil {
+		t.Fatalf("typed key is not a group 14 key: %s", err)
+	}
+}
+
+func TestInfo(t *testing.T) {
+	info := Info()
+	if info.ModulusBits != 2048 || info.PublicKeySize != PublicKeySize {
+		t.Errorf("unexpected sizes: %+v", info)
+	}
+	if info.Name != "RFC 3526 MODP 2048 (group 14)" || info.RFC != "RFC 3526" {
+		t.Errorf("unexpected name: %+v", info)
+	}
+	names := map[*Group]string{
+		Group15:   "RFC 3526 MODP 3072 (group 15)",
+		Group16:   "RFC 3526 MODP 4096 (group 16)",
+		FFDHE2048: "RFC 7919 ffdhe2048",
+	}
+	for _, v := range groups[1:] {
+		info := v.group.Info()
+		if info.Name != names[v.group] || info.ModulusBits != v.bits || info.PublicKeySize != v.keySize {
+			t.Errorf("%s: unexpected info %+v", v.name, info)
+		}
+	}
+	// Groups constructed by callers have no name.
+	custom := &Group{Modulus: modulus, Generator: big.NewInt(2), PrivateKeySize: PrivateKeySize, PublicKeySize: PublicKeySize}
+	if info := custom.Info(); info.Name != "" || info.RFC != "" || info.ModulusBits != 2048 {
+		t.Errorf("custom group: unexpected info %+v", info)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	// Computed independently from the RFC 3526 parameters.
+	const group14 = "1d9becbbb71b0fe7"
+	const group15 = "ed33d605409057fa"
+	fp := Fingerprint()
+	if got := hex.EncodeToString(fp[:]); got != group14 {
+		t.Fatalf("group #14: expected %s, got %s", group14, got)
+	}
+	fp = Group15.Fingerprint()
+	if got := hex.EncodeToString(fp[:]); got != group15 {
+		t.Fatalf("group #15: expected %s, got %s", group15, got)
+	}
+	// Groups with other parameters have other fingerprints.
+	seen := make(map[[8]byte]string)
+	for _, v := range allGroups() {
+		fp := v.group.Fingerprint()
+		if name, ok := seen[fp]; ok {
+			t.Errorf("%s and %s have the same fingerprint", v.name, name)
+		}
+		seen[fp] = v.name
+	}
+	g := &Group{Modulus: modulus, Generator: big.NewInt(5), PrivateKeySize: PrivateKeySize, PublicKeySize: PublicKeySize}
+	if g.Fingerprint() == Group14.Fingerprint() {
+		t.Errorf("fingerprint doesn't depend on the generator")
+	}
+}
+
+func TestOperationCost(t *testing.T) {
+	cost := OperationCost()
+	if cost <= 0 {
+		t.Fatalf("cost is %v", cost)
+	}
+	if OperationCost() != cost {
+		t.Fatalf("cost is not cached")
+	}
+	// Group #15 exponentiations are more expensive.
+	if Group15.OperationCost() <= cost {
+		t.Errorf("group 15 cost %v is not greater than group 14 cost %v", Group15.OperationCost(), cost)
+	}
+}
+
+func TestDeterministicBlinding(t *testing.T) {
+	defer func(p bool) { parallelExp = p }(parallelExp)
+	parallelExp = false
+
+	base := new(big.Int).SetBytes(golden.publicKey1)
+	e := new(big.Int).Add(new(big.Int).SetBytes(golden.privateKey2), new(big.Int).Lsh(one, 258))
+	expected, err := Group14.modExp(Group14.baseExp(base), golden.privateKey2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blindings := [][]byte{
+		make([]byte, PrivateKeySize),
+		bytes.Repeat([]byte{0xff}, PrivateKeySize),
+		golden.privateKey1,
+		golden.privateKey2,
+	}
+	for i, b := range blindings {
+		// Record exponents passed to the exponentiation function.
+		var exponents []*big.Int
+		exp := func(z, x *big.Int) *big.Int {
+			exponents = append(exponents, new(big.Int).Set(x))
+			return Group14.baseExp(base)(z, x)
+		}
+		result, err := Group14.blindedModExp(context.Background(), bytes.NewReader(b), exp, golden.privateKey2)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if !bytes.Equal(result, expected) {
+			t.Fatalf("%d: blinded result differs from unblinded result", i)
+		}
+		blinding := new(big.Int).Add(new(big.Int).SetBytes(b), new(big.Int).Lsh(one, 256))
+		if len(exponents) != 2 || exponents[0].Cmp(blinding) != 0 {
+			t.Fatalf("%d: first exponent is not the blinding value", i)
+		}
+		if sum := new(big.Int).Add(exponents[0], exponents[1]); sum.Cmp(e) != 0 {
+			t.Fatalf("%d: blinded exponents do not sum to the exponent", i)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	// ErrShortPublicKey is returned when fewer than PublicKeySize bytes of
+	// the peer's public key could be read. It wraps the underlying error.
+	ErrShortPublicKey = errors.New("dhgroup14: short public key")
+
+	// ErrInvalidPeerPublicKey is returned when the peer's public key fails
+	// validation. It wraps the validation error.
+	ErrInvalidPeerPublicKey = errors.New("dhgroup14: invalid peer public key")
+)
+
+// Handshake performs an spiped-style key exchange over conn: it generates
+// an ephemeral key pair, sends the PublicKeySize-byte public key, reads
+// exactly PublicKeySize bytes of the peer's public key, validates it, and
+// returns the shared key. The ephemeral private key is zeroized.
+//
+// The initiator writes its public key before reading the peer's; the other
+// side reads first. This allows handshakes over unbuffered connections,
+// such as net.Pipe.
+//
+// Random bytes are read from rand, which must be set to a CSPRNG, such as
+// crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func Handshake(conn io.ReadWriter, rand io.Reader, initiator bool) (sharedKey []byte, err error) {
+	publicKey, privateKey, err := Group14.GenerateKeyPair(rand)
+	if err != nil {
+		return nil, err
+	}
+	defer Zeroize(privateKey)
+
+	var theirPublicKey []byte
+	if initiator {
+		if _, err := WritePublicKey(conn, publicKey); err != nil {
+			return nil, err
+		}
+		if theirPublicKey, err = readPeerPublicKey(conn); err != nil {
+			return nil, err
+		}
+	} else {
+		if theirPublicKey, err = readPeerPublicKey(conn); err != nil {
+			return nil, err
+		}
+		if _, err := WritePublicKey(conn, publicKey); err != nil {
+			return nil, err
+		}
+	}
+	peer, err := Group14.NewPeerKey(theirPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPeerPublicKey, err)
+	}
+	return peer.SharedKey(rand, privateKey)
+}
+
+// DeriveSessionKey performs Handshake over conn and returns n bytes derived
+// from the shared key with HKDF-SHA256 using the given salt and info. The
+// ephemeral private key and the shared key are zeroized before returning,
+// so only the session key remains, which gives forward secrecy once the
+// caller discards it.
+//
+// Random bytes are read from rand, which must be set to a CSPRNG, such as
+// crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func DeriveSessionKey(rand io.Reader, conn io.ReadWriter, initiator bool, salt, info []byte, n int) ([]byte, error) {
+	sharedKey, err := Handshake(conn, rand, initiator)
+	if err != nil {
+		return nil, err
+	}
+	defer Zeroize(sharedKey)
+	return hkdf.Key(sha256.New, sharedKey, salt, string(info), n)
+}
+
+// readPeerPublicKey reads exactly PublicKeySize bytes from r.
+func readPeerPublicKey(r io.Reader) ([]byte, error) {
+	publicKey := make([]byte, PublicKeySize)
+	if _, err := io.ReadFull(r, publicKey); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrShortPublicKey, err)
+	}
+	return publicKey, nil
+}
+
+// WritePublicKey writes publicKey, which must be exactly PublicKeySize
+// bytes, to w. It returns the number of bytes written.
+func WritePublicKey(w io.Writer, publicKey []byte) (int, error) {
+	if len(publicKey) != PublicKeySize {
+		return 0, ErrWrongPublicKeySize
+	}
+	return w.Write(publicKey)
+}
+
+// ReadPublicKey reads exactly PublicKeySize bytes of a public key from r and
+// validates it with ValidatePublicKey. If fewer bytes could be read, the
+// error wraps ErrShortPublicKey and the read error, which is io.EOF if no
+// bytes were read and io.ErrUnexpectedEOF if some were, unless r returned
+// another error. If the key is invalid, the error wraps
+// ErrInvalidPeerPublicKey and the validation error. Callers can thus tell a
+// dropped connection, which may be retried, from a protocol violation.
+func ReadPublicKey(r io.Reader) ([]byte, error) {
+	publicKey, err := readPeerPublicKey(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := Group14.ValidatePublicKey(publicKey); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPeerPublicKey, err)
+	}
+	return publicKey, nil
+}
@@ -0,0 +1,163 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"testing"
+)
+
+type handshakeResult struct {
+	sharedKey []byte
+	err       error
+}
+
+func TestHandshake(t *testing.T) {
+	c1, c2 := net.Pipe()
+	defer c1.Close()
+	defer c2.Close()
+	ch := make(chan handshakeResult)
+	go func() {
+		sharedKey, err := Handshake(c2, rand.Reader, false)
+		ch <- handshakeResult{sharedKey, err}
+	}()
+	sharedKey1, err := Handshake(c1, rand.Reader, true)
+	if err != nil {
+		t.Fatalf("initiator: %s", err)
+	}
+	r := <-ch
+	if r.err != nil {
+		t.Fatalf("responder: %s", r.err)
+	}
+	if !bytes.Equal(sharedKey1, r.sharedKey) {
+		t.Fatalf("two shared keys are not equal!")
+	}
+}
+
+// peerConn reads from peer and discards writes.
+type peerConn struct {
+	io.Reader
+}
+
+func (peerConn) Write(p []byte) (int, error) { return len(p), nil }
+
+func TestHandshakeErrors(t *testing.T) {
+	short := peerConn{bytes.NewReader(golden.publicKey1[:100])}
+	if _, err := Handshake(short, rand.Reader, true); !errors.Is(err, ErrShortPublicKey) || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("short read: unexpected error %v", err)
+	}
+	empty := peerConn{bytes.NewReader(nil)}
+	if _, err := Handshake(empty, rand.Reader, false); !errors.Is(err, ErrShortPublicKey) || !errors.Is(err, io.EOF) {
+		t.Errorf("empty read: unexpected error %v", err)
+	}
+	invalid := peerConn{bytes.NewReader(publicKeyBytes(big.NewInt(1)))}
+	if _, err := Handshake(invalid, rand.Reader, true); !errors.Is(err, ErrInvalidPeerPublicKey) || !errors.Is(err, ErrDegeneratePublicKey) {
+		t.Errorf("invalid key: unexpected error %v", err)
+	}
+	// Only PublicKeySize bytes must be read.
+	r := bytes.NewReader(append(append([]byte(nil), golden.publicKey1...), 0xaa))
+	if _, err := Handshake(peerConn{r}, rand.Reader, true); err != nil {
+		t.Errorf("valid key: %s", err)
+	}
+	if r.Len() != 1 {
+		t.Errorf("read %d bytes past public key", 1-r.Len())
+	}
+	// A randomness failure after key generation is not the peer's fault.
+	failing := io.MultiReader(io.LimitReader(rand.Reader, 2*PrivateKeySize), errorReader{})
+	valid := peerConn{bytes.NewReader(golden.publicKey1)}
+	if _, err := Handshake(valid, failing, true); !errors.Is(err, ErrRandomnessFailed) || errors.Is(err, ErrInvalidPeerPublicKey) {
+		t.Errorf("randomness failure: unexpected error %v", err)
+	}
+}
+
+func TestWriteReadPublicKey(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := WritePublicKey(&buf, golden.publicKey1)
+	if err != nil || n != PublicKeySize {
+		t.Fatalf("write: got %d, %v", n, err)
+	}
+	publicKey, err := ReadPublicKey(&buf)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if !bytes.Equal(publicKey, golden.publicKey1) {
+		t.Fatalf("read wrong public key")
+	}
+	if _, err := WritePublicKey(&buf, golden.publicKey1[1:]); err != ErrWrongPublicKeySize {
+		t.Errorf("short write: expected %v, got %v", ErrWrongPublicKeySize, err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("short write: wrote %d bytes", buf.Len())
+	}
+	if _, err := ReadPublicKey(bytes.NewReader(golden.publicKey1[:100])); !errors.Is(err, ErrShortPublicKey) || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("short read: unexpected error %v", err)
+	}
+	// p-2 is in range but not in the prime-order subgroup.
+	y := new(big.Int).Sub(Modulus(), big.NewInt(2))
+	if _, err := ReadPublicKey(bytes.NewReader(publicKeyBytes(y))); !errors.Is(err, ErrInvalidPeerPublicKey) || !errors.Is(err, ErrPublicKeyNotInSubgroup) {
+		t.Errorf("invalid key: unexpected error %v", err)
+	}
+}
+
+func TestReadPublicKeyErrors(t *testing.T) {
+	for _, n := range []int64{1, PublicKeySize / 2, PublicKeySize - 1} {
+		_, err := ReadPublicKey(io.LimitReader(bytes.NewReader(golden.publicKey1), n))
+		if !errors.Is(err, ErrShortPublicKey) || !errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, ErrInvalidPeerPublicKey) {
+			t.Errorf("%d bytes: unexpected error %v", n, err)
+		}
+	}
+	_, err := ReadPublicKey(io.LimitReader(bytes.NewReader(golden.publicKey1), 0))
+	if !errors.Is(err, ErrShortPublicKey) || !errors.Is(err, io.EOF) {
+		t.Errorf("no bytes: unexpected error %v", err)
+	}
+	r := io.MultiReader(io.LimitReader(bytes.NewReader(golden.publicKey1), 10), errorReader{})
+	if _, err := ReadPublicKey(r); !errors.Is(err, ErrShortPublicKey) || !errors.Is(err, errRead) {
+		t.Errorf("read error: unexpected error %v", err)
+	}
+	// A full read of an invalid key is a validation failure, not a short read.
+	_, err = ReadPublicKey(io.LimitReader(bytes.NewReader(publicKeyBytes(one)), PublicKeySize))
+	if !errors.Is(err, ErrInvalidPeerPublicKey) || !errors.Is(err, ErrDegeneratePublicKey) || errors.Is(err, ErrShortPublicKey) {
+		t.Errorf("invalid key: unexpected error %v", err)
+	}
+}
+
+func TestDeriveSessionKey(t *testing.T) {
+	c1, c2 := net.Pipe()
+	defer c1.Close()
+	defer c2.Close()
+	salt, info := []byte("salt"), []byte("dhgroup14 session")
+	ch := make(chan handshakeResult)
+	go func() {
+		key, err := DeriveSessionKey(rand.Reader, c2, false, salt, info, 32)
+		ch <- handshakeResult{key, err}
+	}()
+	key1, err := DeriveSessionKey(rand.Reader, c1, true, salt, info, 32)
+	if err != nil {
+		t.Fatalf("initiator: %s", err)
+	}
+	r := <-ch
+	if r.err != nil {
+		t.Fatalf("responder: %s", r.err)
+	}
+	if len(key1) != 32 || !bytes.Equal(key1, r.sharedKey) {
+		t.Fatalf("session keys are not equal")
+	}
+	short := peerConn{bytes.NewReader(golden.publicKey1[:100])}
+	if _, err := DeriveSessionKey(rand.Reader, short, true, salt, info, 32); !errors.Is(err, ErrShortPublicKey) {
+		t.Fatalf("short read: unexpected error %v", err)
+	}
+	valid := peerConn{bytes.NewReader(golden.publicKey1)}
+	if _, err := DeriveSessionKey(rand.Reader, valid, true, salt, info, 255*32+1); err == nil {
+		t.Fatalf("accepted too large output length")
+	}
+}
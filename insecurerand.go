@@ -0,0 +1,33 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"errors"
+	"io"
+	mathrand "math/rand"
+)
+
+// ErrInsecureRand is returned by WarnIfInsecureRand for random sources that
+// are known not to be cryptographically secure.
+var ErrInsecureRand = errors.New("dhgroup14: random source is not cryptographically secure")
+
+// WarnIfInsecureRand returns ErrInsecureRand if rand is a recognizably weak
+// random source, such as *math/rand.Rand, which is a common mistake in place
+// of crypto/rand.Reader. It returns nil for all other sources, including
+// nil, which means crypto/rand.Reader. The check is best-effort: most weak
+// sources cannot be recognized.
+//
+// When the package is built with the dhgroup14debug build tag, key
+// generation functions perform this check and log a warning.
+func WarnIfInsecureRand(rand io.Reader) error {
+	if _, ok := rand.(*mathrand.Rand); ok {
+		return ErrInsecureRand
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"crypto/rand"
+	"io"
+	mathrand "math/rand"
+	"testing"
+)
+
+func TestWarnIfInsecureRand(t *testing.T) {
+	if err := WarnIfInsecureRand(mathrand.New(mathrand.NewSource(1))); err != ErrInsecureRand {
+		t.Errorf("math/rand: expected %v, got %v", ErrInsecureRand, err)
+	}
+	for _, r := range []io.Reader{rand.Reader, nil, new(countingReader)} {
+		if err := WarnIfInsecureRand(r); err != nil {
+			t.Errorf("%T: unexpected error %v", r, err)
+		}
+	}
+}
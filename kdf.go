@@ -0,0 +1,184 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"io"
+)
+
+// ErrWrongTruncatedKeySize is returned by SharedKeyTruncated if the
+// requested length is negative or greater than the shared key length.
+var ErrWrongTruncatedKeySize = errors.New("dhgroup14: wrong truncated key size")
+
+// DeriveKey computes a shared key between theirPublicKey and myPrivateKey
+// and returns outLen bytes derived from it with HKDF-SHA256 using the given
+// salt and info. The shared key itself is zeroized.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func DeriveKey(rand io.Reader, theirPublicKey, myPrivateKey, salt, info []byte, outLen int) ([]byte, error) {
+	return DeriveKeyUsing(sha256.New, rand, theirPublicKey, myPrivateKey, salt, info, outLen)
+}
+
+// DeriveKeyUsing is like DeriveKey, but uses HKDF with the hash function h,
+// such as sha512.New, instead of SHA-256.
+func DeriveKeyUsing(h func() hash.Hash, rand io.Reader, theirPublicKey, myPrivateKey, salt, info []byte, outLen int) ([]byte, error) {
+	sharedKey, err := SharedKey(rand, theirPublicKey, myPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	defer Zeroize(sharedKey)
+	return hkdf.Key(h, sharedKey, salt, string(info), outLen)
+}
+
+// SharedSecret is the result of key agreement. It is opaque to discourage
+// using the raw shared key as a symmetric key: use Derive to obtain key
+// material.
+type SharedSecret struct {
+	sharedKey []byte
+}
+
+// Agree computes a shared key between theirPublicKey and myPrivateKey, like
+// SharedKey, and returns it as a SharedSecret.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func Agree(rand io.Reader, theirPublicKey, myPrivateKey []byte) (*SharedSecret, error) {
+	sharedKey, err := SharedKey(rand, theirPublicKey, myPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &SharedSecret{sharedKey: sharedKey}, nil
+}
+
+// Derive returns n bytes derived from the shared secret with HKDF-SHA256
+// using the given salt and info.
+func (s *SharedSecret) Derive(salt, info []byte, n int) ([]byte, error) {
+	return s.DeriveUsing(sha256.New, salt, info, n)
+}
+
+// DeriveUsing is like Derive, but uses HKDF with the hash function h instead
+// of SHA-256.
+func (s *SharedSecret) DeriveUsing(h func() hash.Hash, salt, info []byte, n int) ([]byte, error) {
+	return hkdf.Key(h, s.sharedKey, salt, string(info), n)
+}
+
+// Bytes returns a copy of the raw SharedKeySize-byte shared key. It is an
+// explicit escape hatch for protocols that need the raw value; prefer
+// Derive.
+func (s *SharedSecret) Bytes() []byte {
+	return append([]byte(nil), s.sharedKey...)
+}
+
+// String implements fmt.Stringer. It does not reveal the shared secret.
+func (s *SharedSecret) String() string {
+	return "dhgroup14.SharedSecret(REDACTED)"
+}
+
+// SharedKeySHA256 computes a shared key between theirPublicKey and
+// myPrivateKey and returns its SHA-256 hash, suitable for use as a 32-byte
+// symmetric key. The shared key itself is zeroized.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func SharedKeySHA256(rand io.Reader, theirPublicKey, myPrivateKey []byte) ([32]byte, error) {
+	sharedKey, err := SharedKey(rand, theirPublicKey, myPrivateKey)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	defer Zeroize(sharedKey)
+	return sha256.Sum256(sharedKey), nil
+}
+
+// SharedKeyTruncated computes a shared key between theirPublicKey and
+// myPrivateKey and returns its first n bytes. The full shared key is
+// zeroized. It returns ErrWrongTruncatedKeySize if n is negative or greater
+// than the length of the shared key, which is DefaultGroup.PublicKeySize.
+//
+// Truncation is provided for protocols that specify it. The leading bytes
+// of a shared key are not uniformly random, so raw truncation is weaker than
+// key derivation: use DeriveKey unless the protocol requires otherwise.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func SharedKeyTruncated(rand io.Reader, theirPublicKey, myPrivateKey []byte, n int) ([]byte, error) {
+	if n < 0 || n > DefaultGroup.PublicKeySize {
+		return nil, ErrWrongTruncatedKeySize
+	}
+	sharedKey, err := SharedKey(rand, theirPublicKey, myPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	defer Zeroize(sharedKey)
+	return append([]byte(nil), sharedKey[:n]...), nil
+}
+
+// SharedKeyWithTranscript computes a shared key between theirPublicKey and
+// myPrivateKey, like SharedKey, together with a transcript hash binding it
+// to both public keys, which can serve as a channel binding value:
+//
+//	transcriptHash = SHA-256(min(pubA, pubB) || max(pubA, pubB) || sharedKey)
+//
+// The public keys are ordered by comparing them as big-endian numbers, which
+// for equal-length keys is the same as comparing them as byte strings, so
+// both parties compute the same hash regardless of which one initiated.
+//
+// The own public key is recomputed from myPrivateKey, so
+// SharedKeyWithTranscript takes an extra exponentiation and reads
+// 2*PrivateKeySize random bytes.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func SharedKeyWithTranscript(rand io.Reader, theirPublicKey, myPrivateKey []byte) (sharedKey, transcriptHash []byte, err error) {
+	return SharedKeyWithTranscriptUsing(sha256.New, rand, theirPublicKey, myPrivateKey)
+}
+
+// SharedKeyWithTranscriptUsing is like SharedKeyWithTranscript, but computes
+// the transcript hash with the hash function h instead of SHA-256.
+func SharedKeyWithTranscriptUsing(h func() hash.Hash, rand io.Reader, theirPublicKey, myPrivateKey []byte) (sharedKey, transcriptHash []byte, err error) {
+	rand = randReader(rand)
+	sharedKey, err = SharedKey(rand, theirPublicKey, myPrivateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	myPublicKey, err := GeneratePublicKey(rand, myPrivateKey)
+	if err != nil {
+		Zeroize(sharedKey)
+		return nil, nil, err
+	}
+	first, second := myPublicKey, theirPublicKey
+	if bytes.Compare(first, second) > 0 {
+		first, second = second, first
+	}
+	th := h()
+	th.Write(first)
+	th.Write(second)
+	th.Write(sharedKey)
+	return sharedKey, th.Sum(nil), nil
+}
+
+// ConfirmationTag returns HMAC-SHA256 of transcript keyed by sharedKey,
+// which peers can exchange after key agreement to confirm that they derived
+// the same key and saw the same transcript.
+func ConfirmationTag(sharedKey, transcript []byte) []byte {
+	mac := hmac.New(sha256.New, sharedKey)
+	mac.Write(transcript)
+	return mac.Sum(nil)
+}
+
+// VerifyConfirmationTag reports whether tag is the confirmation tag of
+// transcript under sharedKey. Tags are compared in constant time.
+func VerifyConfirmationTag(sharedKey, transcript, tag []byte) bool {
+	return Equal(ConfirmationTag(sharedKey, transcript), tag)
+}
@@ -0,0 +1,236 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"testing"
+)
+
+func TestDeriveKey(t *testing.T) {
+	// HKDF-SHA256 of golden.sharedKey, computed independently.
+	const expected = "82c6c5a9e6ff0772bd06e785419d2eacc1487f23919cd9ebec65eac7d6c7b2add8f9340f6b3bc3481c4c"
+	key, err := DeriveKey(rand.Reader, golden.publicKey1, golden.privateKey2, []byte("salt"), []byte("dhgroup14 test"), 42)
+	if err != nil {
+		t.Fatalf("derive key: %s", err)
+	}
+	if got := hex.EncodeToString(key); got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+	if _, err := DeriveKey(rand.Reader, golden.publicKey1[1:], golden.privateKey2, nil, nil, 32); err == nil {
+		t.Fatalf("accepted wrong public key")
+	}
+	if _, err := DeriveKey(rand.Reader, golden.publicKey1, golden.privateKey2, nil, nil, 255*32+1); err == nil {
+		t.Fatalf("accepted too large output length")
+	}
+}
+
+func TestDeriveKeyUsing(t *testing.T) {
+	// HKDF of golden.sharedKey with other hashes, computed independently.
+	tests := []struct {
+		name     string
+		h        func() hash.Hash
+		expected string
+	}{
+		{"SHA-256", sha256.New, "82c6c5a9e6ff0772bd06e785419d2eacc1487f23919cd9ebec65eac7d6c7b2add8f9340f6b3bc3481c4c"},
+		{"SHA-384", sha512.New384, "07e899897daa2fb7605f20176a2df5470645482f8517e0156f33ff276d3f423d9944ee376031ecafb9e9"},
+		{"SHA-512", sha512.New, "2d0e3dafe274eb9a46ee8eee373c54b68aa97c858dac85d51392c3a3591b3184327375c8e89d6c1410a5"},
+	}
+	secret, err := Agree(rand.Reader, golden.publicKey1, golden.privateKey2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range tests {
+		key, err := DeriveKeyUsing(v.h, rand.Reader, golden.publicKey1, golden.privateKey2, []byte("salt"), []byte("dhgroup14 test"), 42)
+		if err != nil {
+			t.Fatalf("%s: derive key: %s", v.name, err)
+		}
+		if got := hex.EncodeToString(key); got != v.expected {
+			t.Errorf("%s: expected %s, got %s", v.name, v.expected, got)
+		}
+		key, err = secret.DeriveUsing(v.h, []byte("salt"), []byte("dhgroup14 test"), 42)
+		if err != nil {
+			t.Fatalf("%s: derive: %s", v.name, err)
+		}
+		if got := hex.EncodeToString(key); got != v.expected {
+			t.Errorf("%s: DeriveUsing: expected %s, got %s", v.name, v.expected, got)
+		}
+	}
+}
+
+func TestSharedKeySHA256(t *testing.T) {
+	key, err := SharedKeySHA256(rand.Reader, golden.publicKey1, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("compute key: %s", err)
+	}
+	if expected := sha256.Sum256(golden.sharedKey); key != expected {
+		t.Fatalf("expected %x, got %x", expected, key)
+	}
+	if _, err := SharedKeySHA256(rand.Reader, golden.publicKey1[1:], golden.privateKey2); err == nil {
+		t.Fatalf("accepted wrong public key")
+	}
+}
+
+func TestSharedKeyWithTranscript(t *testing.T) {
+	sharedKey1, transcript1, err := SharedKeyWithTranscript(rand.Reader, golden.publicKey2, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("party 1: %s", err)
+	}
+	sharedKey2, transcript2, err := SharedKeyWithTranscript(rand.Reader, golden.publicKey1, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("party 2: %s", err)
+	}
+	if !bytes.Equal(sharedKey1, golden.sharedKey) || !bytes.Equal(sharedKey2, golden.sharedKey) {
+		t.Fatalf("shared keys don't match")
+	}
+	if !bytes.Equal(transcript1, transcript2) {
+		t.Fatalf("transcript hashes differ: %x and %x", transcript1, transcript2)
+	}
+	first, second := golden.publicKey1, golden.publicKey2
+	if bytes.Compare(first, second) > 0 {
+		first, second = second, first
+	}
+	expected := sha256.Sum256(append(append(append([]byte(nil), first...), second...), golden.sharedKey...))
+	if !bytes.Equal(transcript1, expected[:]) {
+		t.Fatalf("expected transcript hash %x, got %x", expected, transcript1)
+	}
+	if _, _, err := SharedKeyWithTranscript(rand.Reader, golden.publicKey1[1:], golden.privateKey2); err != ErrWrongPublicKeySize {
+		t.Fatalf("expected %v, got %v", ErrWrongPublicKeySize, err)
+	}
+}
+
+func TestSharedKeyWithTranscriptUsing(t *testing.T) {
+	_, transcript1, err := SharedKeyWithTranscriptUsing(sha512.New, rand.Reader, golden.publicKey2, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("party 1: %s", err)
+	}
+	_, transcript2, err := SharedKeyWithTranscriptUsing(sha512.New, rand.Reader, golden.publicKey1, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("party 2: %s", err)
+	}
+	if len(transcript1) != sha512.Size || !bytes.Equal(transcript1, transcript2) {
+		t.Fatalf("transcript hashes differ: %x and %x", transcript1, transcript2)
+	}
+	_, transcript256, err := SharedKeyWithTranscript(rand.Reader, golden.publicKey2, golden.privateKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(transcript1[:sha256.Size], transcript256) {
+		t.Fatalf("hash function ignored")
+	}
+}
+
+func TestSharedKeyTruncated(t *testing.T) {
+	for _, n := range []int{0, 16, 32, SharedKeySize} {
+		key, err := SharedKeyTruncated(rand.Reader, golden.publicKey1, golden.privateKey2, n)
+		if err != nil {
+			t.Fatalf("%d: %s", n, err)
+		}
+		if !bytes.Equal(key, golden.sharedKey[:n]) {
+			t.Fatalf("%d: expected %x, got %x", n, golden.sharedKey[:n], key)
+		}
+	}
+	for _, n := range []int{-1, SharedKeySize + 1} {
+		if _, err := SharedKeyTruncated(rand.Reader, golden.publicKey1, golden.privateKey2, n); err != ErrWrongTruncatedKeySize {
+			t.Fatalf("%d: expected %v, got %v", n, ErrWrongTruncatedKeySize, err)
+		}
+	}
+	if _, err := SharedKeyTruncated(rand.Reader, golden.publicKey1[1:], golden.privateKey2, 32); err == nil {
+		t.Fatalf("accepted wrong public key")
+	}
+}
+
+func TestSharedKeyTruncatedDefaultGroup(t *testing.T) {
+	defer func(g *Group) { DefaultGroup = g }(DefaultGroup)
+	DefaultGroup = Group16
+
+	publicKey, err := GeneratePublicKey(rand.Reader, golden.privateKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sharedKey, err := SharedKey(rand.Reader, publicKey, golden.privateKey2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []int{300, Group16.PublicKeySize} {
+		key, err := SharedKeyTruncated(rand.Reader, publicKey, golden.privateKey2, n)
+		if err != nil {
+			t.Fatalf("%d: %s", n, err)
+		}
+		if !bytes.Equal(key, sharedKey[:n]) {
+			t.Fatalf("%d: wrong truncated key", n)
+		}
+	}
+	n := Group16.PublicKeySize + 1
+	if _, err := SharedKeyTruncated(rand.Reader, publicKey, golden.privateKey2, n); err != ErrWrongTruncatedKeySize {
+		t.Fatalf("%d: expected %v, got %v", n, ErrWrongTruncatedKeySize, err)
+	}
+}
+
+func TestAgree(t *testing.T) {
+	secret, err := Agree(rand.Reader, golden.publicKey1, golden.privateKey2)
+	if err != nil {
+		t.Fatalf("agree: %s", err)
+	}
+	b := secret.Bytes()
+	if !bytes.Equal(b, golden.sharedKey) {
+		t.Fatalf("wrong shared secret")
+	}
+	b[0] ^= 1
+	if !bytes.Equal(secret.Bytes(), golden.sharedKey) {
+		t.Fatalf("Bytes does not return a copy")
+	}
+	key, err := secret.Derive([]byte("salt"), []byte("dhgroup14 test"), 42)
+	if err != nil {
+		t.Fatalf("derive: %s", err)
+	}
+	expected, err := DeriveKey(rand.Reader, golden.publicKey1, golden.privateKey2, []byte("salt"), []byte("dhgroup14 test"), 42)
+	if err != nil {
+		t.Fatalf("derive key: %s", err)
+	}
+	if !bytes.Equal(key, expected) {
+		t.Fatalf("Derive and DeriveKey differ")
+	}
+	if s := fmt.Sprintf("%v %+v", secret, secret); s != "dhgroup14.SharedSecret(REDACTED) dhgroup14.SharedSecret(REDACTED)" {
+		t.Fatalf("formatted secret is not redacted: %s", s)
+	}
+	if _, err := Agree(rand.Reader, golden.publicKey1[1:], golden.privateKey2); err == nil {
+		t.Fatalf("accepted wrong public key")
+	}
+}
+
+func TestConfirmationTag(t *testing.T) {
+	transcript := append(append([]byte(nil), golden.publicKey1...), golden.publicKey2...)
+	tag := ConfirmationTag(golden.sharedKey, transcript)
+	if len(tag) != sha256.Size {
+		t.Fatalf("tag is %d bytes", len(tag))
+	}
+	if !VerifyConfirmationTag(golden.sharedKey, transcript, tag) {
+		t.Fatalf("valid tag rejected")
+	}
+	if VerifyConfirmationTag(golden.sharedKey, transcript[1:], tag) {
+		t.Fatalf("tag for different transcript accepted")
+	}
+	if VerifyConfirmationTag(golden.sharedKey[1:], transcript, tag) {
+		t.Fatalf("tag for different key accepted")
+	}
+	badTag := append([]byte(nil), tag...)
+	badTag[0] ^= 1
+	if VerifyConfirmationTag(golden.sharedKey, transcript, badTag) {
+		t.Fatalf("modified tag accepted")
+	}
+	if VerifyConfirmationTag(golden.sharedKey, transcript, tag[:16]) {
+		t.Fatalf("truncated tag accepted")
+	}
+}
@@ -0,0 +1,50 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import "io"
+
+// KeyExchanger is a Diffie-Hellman key exchange operating on byte slices,
+// which lets code switch between this package and other key exchanges, such
+// as X25519, behind one interface. *Group implements KeyExchanger; use
+// DefaultGroup or Group14 for group #14.
+//
+// Code migrating from curve25519 should not assume that keys are 32 bytes.
+// Private keys of group #14 are 32 bytes as with X25519, but public and
+// shared keys are 256 bytes rather than 32, so buffers, wire formats and
+// length checks must use PublicKeyLen and SharedKeyLen. Like an X25519
+// output, a shared key is not uniformly random and should be passed through
+// a key derivation function, such as HKDF, before use.
+type KeyExchanger interface {
+	// GenerateKeyPair generates a new random key pair.
+	GenerateKeyPair(rand io.Reader) (publicKey, privateKey []byte, err error)
+
+	// SharedKey returns the shared key between theirPublicKey and
+	// myPrivateKey, validating theirPublicKey.
+	SharedKey(rand io.Reader, theirPublicKey, myPrivateKey []byte) (sharedKey []byte, err error)
+
+	// PublicKeyLen returns the length of public keys in bytes.
+	PublicKeyLen() int
+
+	// SharedKeyLen returns the length of shared keys in bytes.
+	SharedKeyLen() int
+}
+
+var _ KeyExchanger = (*Group)(nil)
+
+// PublicKeyLen returns the length of public keys of group g in bytes, which
+// is PublicKeySize.
+func (g *Group) PublicKeyLen() int {
+	return g.PublicKeySize
+}
+
+// SharedKeyLen returns the length of shared keys of group g in bytes, which
+// is the same as the length of public keys.
+func (g *Group) SharedKeyLen() int {
+	return g.PublicKeySize
+}
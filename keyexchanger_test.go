@@ -0,0 +1,87 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"io"
+	"testing"
+)
+
+// x25519 implements KeyExchanger with X25519 from crypto/ecdh.
+type x25519 struct{}
+
+func (x25519) GenerateKeyPair(rand io.Reader) (publicKey, privateKey []byte, err error) {
+	k, err := ecdh.X25519().GenerateKey(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	return k.PublicKey().Bytes(), k.Bytes(), nil
+}
+
+func (x25519) SharedKey(rand io.Reader, theirPublicKey, myPrivateKey []byte) ([]byte, error) {
+	priv, err := ecdh.X25519().NewPrivateKey(myPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := ecdh.X25519().NewPublicKey(theirPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return priv.ECDH(pub)
+}
+
+func (x25519) PublicKeyLen() int { return 32 }
+func (x25519) SharedKeyLen() int { return 32 }
+
+// exchange runs a key exchange between two parties with kx.
+func exchange(t *testing.T, kx KeyExchanger) {
+	publicKey1, privateKey1, err := kx.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key pair 1: %s", err)
+	}
+	publicKey2, privateKey2, err := kx.GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key pair 2: %s", err)
+	}
+	if len(publicKey1) != kx.PublicKeyLen() || len(publicKey2) != kx.PublicKeyLen() {
+		t.Fatalf("public keys are %d and %d bytes, expected %d", len(publicKey1), len(publicKey2), kx.PublicKeyLen())
+	}
+	sharedKey1, err := kx.SharedKey(rand.Reader, publicKey2, privateKey1)
+	if err != nil {
+		t.Fatalf("compute shared key 1: %s", err)
+	}
+	sharedKey2, err := kx.SharedKey(rand.Reader, publicKey1, privateKey2)
+	if err != nil {
+		t.Fatalf("compute shared key 2: %s", err)
+	}
+	if len(sharedKey1) != kx.SharedKeyLen() || !bytes.Equal(sharedKey1, sharedKey2) {
+		t.Fatalf("shared keys don't match")
+	}
+	if _, err := kx.SharedKey(rand.Reader, publicKey1[1:], privateKey2); err == nil {
+		t.Fatalf("accepted short public key")
+	}
+}
+
+func TestKeyExchanger(t *testing.T) {
+	for _, v := range []struct {
+		name string
+		kx   KeyExchanger
+	}{
+		{"group14", Group14},
+		{"ffdhe2048", FFDHE2048},
+		{"x25519", x25519{}},
+	} {
+		t.Run(v.name, func(t *testing.T) { exchange(t, v.kx) })
+	}
+	if Group14.PublicKeyLen() != PublicKeySize || Group14.SharedKeyLen() != SharedKeySize {
+		t.Fatalf("wrong group #14 key lengths")
+	}
+}
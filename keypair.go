@@ -0,0 +1,78 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrWrongKeyPairSize is returned by KeyPair.UnmarshalBinary if the data is
+// not PublicKeySize+PrivateKeySize bytes.
+var ErrWrongKeyPairSize = errors.New("dhgroup14: wrong key pair size")
+
+// KeyPair holds a public key and the corresponding private key.
+type KeyPair struct {
+	Public  []byte // PublicKeySize bytes
+	Private []byte // PrivateKeySize bytes
+}
+
+// GenerateKeyPairStruct is like GenerateKeyPair, but returns the keys as a
+// KeyPair. Keys are always generated in group #14, regardless of
+// DefaultGroup.
+func GenerateKeyPairStruct(rand io.Reader) (KeyPair, error) {
+	publicKey, privateKey, err := Group14.GenerateKeyPair(rand)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	return KeyPair{Public: publicKey, Private: privateKey}, nil
+}
+
+// KeyPairFromPrivate returns a KeyPair for an existing group #14 private
+// key, such as one derived with a KDF, computing the public key with
+// blinding. It returns ErrWrongPrivateKeySize or ErrWeakPrivateKey if
+// privateKey is not a valid private key. privateKey is copied.
+//
+// Random bytes for blinding are read from rand, which must be set to a
+// CSPRNG, such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is
+// used.
+func KeyPairFromPrivate(rand io.Reader, privateKey []byte) (KeyPair, error) {
+	publicKey, err := Group14.GeneratePublicKey(rand, privateKey)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	return KeyPair{Public: publicKey, Private: ClonePrivateKey(privateKey)}, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is the
+// public key followed by the private key. It returns an error if either key
+// has the wrong size.
+func (kp *KeyPair) MarshalBinary() ([]byte, error) {
+	if len(kp.Public) != PublicKeySize {
+		return nil, ErrWrongPublicKeySize
+	}
+	if len(kp.Private) != PrivateKeySize {
+		return nil, ErrWrongPrivateKeySize
+	}
+	data := make([]byte, 0, PublicKeySize+PrivateKeySize)
+	data = append(data, kp.Public...)
+	return append(data, kp.Private...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It returns
+// ErrWrongKeyPairSize if data is not PublicKeySize+PrivateKeySize bytes.
+// The keys are copied, and are not checked to correspond to each other; use
+// VerifyKeyPair for that.
+func (kp *KeyPair) UnmarshalBinary(data []byte) error {
+	if len(data) != PublicKeySize+PrivateKeySize {
+		return ErrWrongKeyPairSize
+	}
+	kp.Public = append([]byte(nil), data[:PublicKeySize]...)
+	kp.Private = append([]byte(nil), data[PublicKeySize:]...)
+	return nil
+}
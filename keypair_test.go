@@ -0,0 +1,82 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestKeyPairRoundTrip(t *testing.T) {
+	kp, err := GenerateKeyPairStruct(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key pair: %s", err)
+	}
+	data, err := kp.MarshalBinary()
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	if len(data) != PublicKeySize+PrivateKeySize {
+		t.Fatalf("encoding is %d bytes", len(data))
+	}
+	var loaded KeyPair
+	if err := loaded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unmarshal: %s", err)
+	}
+	if !bytes.Equal(loaded.Public, kp.Public) || !bytes.Equal(loaded.Private, kp.Private) {
+		t.Fatalf("loaded key pair differs")
+	}
+	Zeroize(data)
+	if ok, err := VerifyKeyPair(loaded.Public, loaded.Private); err != nil || !ok {
+		t.Fatalf("VerifyKeyPair: got %v, %v", ok, err)
+	}
+}
+
+func TestKeyPairFromPrivate(t *testing.T) {
+	privateKey := append([]byte(nil), golden.privateKey1...)
+	kp, err := KeyPairFromPrivate(rand.Reader, privateKey)
+	if err != nil {
+		t.Fatalf("key pair: %s", err)
+	}
+	if !bytes.Equal(kp.Public, golden.publicKey1) || !bytes.Equal(kp.Private, golden.privateKey1) {
+		t.Fatalf("wrong key pair")
+	}
+	Zeroize(privateKey)
+	if !bytes.Equal(kp.Private, golden.privateKey1) {
+		t.Fatalf("private key not copied")
+	}
+	for _, n := range []int{0, PrivateKeySize - 1, PrivateKeySize + 1} {
+		if _, err := KeyPairFromPrivate(rand.Reader, make([]byte, n)); err != ErrWrongPrivateKeySize {
+			t.Errorf("%d bytes: expected %v, got %v", n, ErrWrongPrivateKeySize, err)
+		}
+	}
+	if _, err := KeyPairFromPrivate(rand.Reader, make([]byte, PrivateKeySize)); err != ErrWeakPrivateKey {
+		t.Errorf("zero key: expected %v, got %v", ErrWeakPrivateKey, err)
+	}
+}
+
+func TestKeyPairErrors(t *testing.T) {
+	var kp KeyPair
+	for _, n := range []int{0, PublicKeySize, PublicKeySize + PrivateKeySize - 1, PublicKeySize + PrivateKeySize + 1} {
+		if err := kp.UnmarshalBinary(make([]byte, n)); err != ErrWrongKeyPairSize {
+			t.Errorf("%d bytes: expected %v, got %v", n, ErrWrongKeyPairSize, err)
+		}
+	}
+	kp = KeyPair{Public: golden.publicKey1[1:], Private: golden.privateKey1}
+	if _, err := kp.MarshalBinary(); err != ErrWrongPublicKeySize {
+		t.Errorf("short public key: expected %v, got %v", ErrWrongPublicKeySize, err)
+	}
+	kp = KeyPair{Public: golden.publicKey1, Private: golden.privateKey1[1:]}
+	if _, err := kp.MarshalBinary(); err != ErrWrongPrivateKeySize {
+		t.Errorf("short private key: expected %v, got %v", ErrWrongPrivateKeySize, err)
+	}
+	if _, err := GenerateKeyPairStruct(errorReader{}); err == nil {
+		t.Errorf("expected error from failing reader")
+	}
+}
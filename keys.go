@@ -0,0 +1,164 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// PrivateKey is a Diffie-Hellman private key.
+type PrivateKey [PrivateKeySize]byte
+
+// PublicKey is a Diffie-Hellman public key.
+type PublicKey [PublicKeySize]byte
+
+// GenerateKey generates new random private key and the corresponding public key.
+//
+// Random bytes for the private key and for blinding are read from rand, which
+// must be set to a CSPRNG, such as crypto/rand.Reader. If rand is nil,
+// crypto/rand.Reader is used.
+func GenerateKey(rand io.Reader) (publicKey *PublicKey, privateKey *PrivateKey, err error) {
+	pub, priv, err := Group14.GenerateKeyPair(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer Zeroize(priv)
+	publicKey, privateKey = new(PublicKey), new(PrivateKey)
+	copy(publicKey[:], pub)
+	copy(privateKey[:], priv)
+	return
+}
+
+// Public returns the public key corresponding to k.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func (k *PrivateKey) Public(rand io.Reader) (*PublicKey, error) {
+	b, err := Group14.GeneratePublicKey(rand, k[:])
+	if err != nil {
+		return nil, err
+	}
+	publicKey := new(PublicKey)
+	copy(publicKey[:], b)
+	return publicKey, nil
+}
+
+// SharedKey returns a shared key between theirPublicKey and k.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func (k *PrivateKey) SharedKey(rand io.Reader, theirPublicKey *PublicKey) (sharedKey []byte, err error) {
+	return Group14.SharedKey(rand, theirPublicKey[:], k[:])
+}
+
+// Clone returns a copy of k. Since PrivateKey is an array, assigning it
+// also copies it; Clone makes the copy explicit when handing a key to code
+// that may zeroize it.
+func (k PrivateKey) Clone() PrivateKey {
+	return k
+}
+
+// Clone returns a copy of k.
+func (k PublicKey) Clone() PublicKey {
+	return k
+}
+
+// String implements fmt.Stringer. It returns a redacted placeholder, so that
+// private keys are not leaked by logging or formatting with %v, %s or %x.
+func (k PrivateKey) String() string {
+	return "dhgroup14.PrivateKey(REDACTED)"
+}
+
+// GoString implements fmt.GoStringer, redacting the key for %#v.
+func (k PrivateKey) GoString() string {
+	return k.String()
+}
+
+// String implements fmt.Stringer. It returns the hex encoding of the key.
+func (k PublicKey) String() string {
+	return hex.EncodeToString(k[:])
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (k *PrivateKey) MarshalBinary() ([]byte, error) {
+	return append([]byte(nil), k[:]...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It returns an error if data is not PrivateKeySize bytes.
+func (k *PrivateKey) UnmarshalBinary(data []byte) error {
+	if len(data) != PrivateKeySize {
+		return ErrWrongPrivateKeySize
+	}
+	copy(k[:], data)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (k *PublicKey) MarshalBinary() ([]byte, error) {
+	return append([]byte(nil), k[:]...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It returns an error if data is not PublicKeySize bytes.
+func (k *PublicKey) UnmarshalBinary(data []byte) error {
+	if len(data) != PublicKeySize {
+		return ErrWrongPublicKeySize
+	}
+	copy(k[:], data)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The key is encoded as a base64
+// string.
+func (k PrivateKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k[:])
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It returns an error if data is
+// not a base64 string encoding PrivateKeySize bytes.
+func (k *PrivateKey) UnmarshalJSON(data []byte) error {
+	b, err := unmarshalJSONKey(data)
+	if err != nil {
+		return err
+	}
+	defer Zeroize(b)
+	return k.UnmarshalBinary(b)
+}
+
+// MarshalJSON implements json.Marshaler. The key is encoded as a base64
+// string.
+func (k PublicKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k[:])
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It returns an error if data is
+// not a base64 string encoding PublicKeySize bytes.
+func (k *PublicKey) UnmarshalJSON(data []byte) error {
+	b, err := unmarshalJSONKey(data)
+	if err != nil {
+		return err
+	}
+	return k.UnmarshalBinary(b)
+}
+
+func unmarshalJSONKey(data []byte) ([]byte, error) {
+	var b []byte
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("dhgroup14: invalid JSON key: %w", err)
+	}
+	// Both null and "" decode to an empty slice.
+	if len(b) == 0 {
+		return nil, errors.New("dhgroup14: empty JSON key")
+	}
+	return b, nil
+}
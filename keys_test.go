@@ -0,0 +1,188 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTypedGenerateKey(t *testing.T) {
+	publicKey1, privateKey1, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key 1: %s", err)
+	}
+	publicKey2, privateKey2, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key 2: %s", err)
+	}
+	sharedKey1, err := privateKey2.SharedKey(rand.Reader, publicKey1)
+	if err != nil {
+		t.Fatalf("compute shared key 1: %s", err)
+	}
+	sharedKey2, err := privateKey1.SharedKey(rand.Reader, publicKey2)
+	if err != nil {
+		t.Fatalf("compute shared key 2: %s", err)
+	}
+	if !bytes.Equal(sharedKey1, sharedKey2) {
+		t.Fatalf("two shared keys are not equal!")
+	}
+}
+
+func TestTypedPublic(t *testing.T) {
+	var privateKey PrivateKey
+	copy(privateKey[:], golden.privateKey1)
+	publicKey, err := privateKey.Public(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating public key: %s", err)
+	}
+	if !bytes.Equal(publicKey[:], golden.publicKey1) {
+		t.Fatalf("generated wrong public key. Expected: %x, got %x", golden.publicKey1, publicKey[:])
+	}
+}
+
+func TestTypedSharedKey(t *testing.T) {
+	var privateKey PrivateKey
+	var publicKey PublicKey
+	copy(privateKey[:], golden.privateKey2)
+	copy(publicKey[:], golden.publicKey1)
+	sharedKey, err := privateKey.SharedKey(rand.Reader, &publicKey)
+	if err != nil {
+		t.Fatalf("compute shared key: %s", err)
+	}
+	if !bytes.Equal(sharedKey, golden.sharedKey) {
+		t.Fatalf(`expecting "%x", got "%x"`, golden.sharedKey, sharedKey)
+	}
+}
+
+func TestKeysGob(t *testing.T) {
+	type keys struct {
+		Private PrivateKey
+		Public  PublicKey
+	}
+	var in, out keys
+	copy(in.Private[:], golden.privateKey1)
+	copy(in.Public[:], golden.publicKey1)
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Fatalf("encode: %s", err)
+	}
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("decode: %s", err)
+	}
+	if in != out {
+		t.Fatalf("decoded keys differ")
+	}
+}
+
+func TestUnmarshalBinaryWrongSize(t *testing.T) {
+	var privateKey PrivateKey
+	if err := privateKey.UnmarshalBinary(golden.privateKey1[1:]); err == nil {
+		t.Errorf("private key: short input accepted")
+	}
+	if err := privateKey.UnmarshalBinary(golden.publicKey1); err == nil {
+		t.Errorf("private key: long input accepted")
+	}
+	var publicKey PublicKey
+	if err := publicKey.UnmarshalBinary(golden.publicKey1[1:]); err == nil {
+		t.Errorf("public key: short input accepted")
+	}
+	if err := publicKey.UnmarshalBinary(append(golden.publicKey1, 0)); err == nil {
+		t.Errorf("public key: long input accepted")
+	}
+}
+
+func TestKeysJSON(t *testing.T) {
+	type keys struct {
+		Private PrivateKey
+		Public  PublicKey
+	}
+	var in, out keys
+	copy(in.Private[:], golden.privateKey1)
+	copy(in.Public[:], golden.publicKey1)
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	if !bytes.Contains(data, []byte(`"Private":"B83t95d++Q4vmmlMbdvK7oMeN2WdbqKXFXLbONwGNtQ="`)) {
+		t.Fatalf("private key is not encoded as base64: %s", data)
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %s", err)
+	}
+	if in != out {
+		t.Fatalf("unmarshaled keys differ")
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	bad := map[string]string{
+		"null":       `null`,
+		"empty":      `""`,
+		"not base64": `"!!!!"`,
+		"number":     `42`,
+		"wrong size": `"AAAA"`,
+	}
+	for name, data := range bad {
+		var privateKey PrivateKey
+		if err := privateKey.UnmarshalJSON([]byte(data)); err == nil {
+			t.Errorf("private key: %s: expected error", name)
+		}
+		var publicKey PublicKey
+		if err := publicKey.UnmarshalJSON([]byte(data)); err == nil {
+			t.Errorf("public key: %s: expected error", name)
+		}
+	}
+}
+
+func TestKeysString(t *testing.T) {
+	var privateKey PrivateKey
+	copy(privateKey[:], golden.privateKey1)
+	hexKey := hex.EncodeToString(golden.privateKey1)
+	for _, s := range []string{
+		privateKey.String(),
+		fmt.Sprint(privateKey),
+		fmt.Sprintf("%v %s %x %X %#v", privateKey, privateKey, privateKey, privateKey, privateKey),
+		fmt.Sprintf("%v %s %x %#v", &privateKey, &privateKey, &privateKey, &privateKey),
+	} {
+		if strings.Contains(strings.ToLower(s), hexKey[:16]) {
+			t.Errorf("formatted private key contains key bytes: %s", s)
+		}
+		if !strings.Contains(s, "REDACTED") {
+			t.Errorf("formatted private key is not redacted: %s", s)
+		}
+	}
+	var publicKey PublicKey
+	copy(publicKey[:], golden.publicKey1)
+	if s := publicKey.String(); s != hex.EncodeToString(golden.publicKey1) {
+		t.Errorf("wrong public key string: %s", s)
+	}
+}
+
+func TestKeysClone(t *testing.T) {
+	var privateKey PrivateKey
+	copy(privateKey[:], golden.privateKey1)
+	clone := privateKey.Clone()
+	Zeroize(clone[:])
+	if !bytes.Equal(privateKey[:], golden.privateKey1) {
+		t.Fatalf("zeroizing clone changed private key")
+	}
+	var publicKey PublicKey
+	copy(publicKey[:], golden.publicKey1)
+	publicClone := publicKey.Clone()
+	publicClone[0] ^= 1
+	if !bytes.Equal(publicKey[:], golden.publicKey1) {
+		t.Fatalf("modifying clone changed public key")
+	}
+}
@@ -0,0 +1,79 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+)
+
+var (
+	// ErrBaseOutOfRange is returned by BlindedModExp if base is not in the
+	// range [1, modulus-1].
+	ErrBaseOutOfRange = errors.New("dhgroup14: base is out of range")
+
+	// ErrNegativeExponent is returned by BlindedModExp if exponent is
+	// negative.
+	ErrNegativeExponent = errors.New("dhgroup14: exponent is negative")
+)
+
+// ModExp returns base^exponent mod modulus as a new big.Int. It panics if
+// exponent is negative.
+//
+// WARNING: this function is not resistant to timing attacks. Use
+// BlindedModExp when exponent is secret.
+func (g *Group) ModExp(base, exponent *big.Int) *big.Int {
+	if exponent.Sign() < 0 {
+		panic(ErrNegativeExponent)
+	}
+	return new(big.Int).Exp(base, exponent, g.Modulus)
+}
+
+// BlindedModExp returns base^exponent mod modulus as a new big.Int,
+// computed with exponent blinding as in SharedKey. Base must be in the
+// range [1, modulus-1] and exponent must not be negative. Unlike SharedKey,
+// BlindedModExp does not add ExponentOffset to exponent and does not
+// validate or reject any results.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader: the timing-attack defense relies on blinding
+// values being unpredictable. If rand is nil, crypto/rand.Reader is used.
+func (g *Group) BlindedModExp(rand io.Reader, base, exponent *big.Int) (*big.Int, error) {
+	if base.Sign() <= 0 || base.Cmp(g.Modulus) >= 0 {
+		return nil, ErrBaseOutOfRange
+	}
+	if exponent.Sign() < 0 {
+		return nil, ErrNegativeExponent
+	}
+	e := getInt().Set(exponent)
+	defer putInt(e)
+	z := new(big.Int)
+	if err := g.blindedExp(context.Background(), randReader(rand), g.baseExp(base), z, e); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// ModExp returns base^exponent mod the group #14 modulus. See Group.ModExp.
+//
+// WARNING: this function is not resistant to timing attacks. Use
+// BlindedModExp when exponent is secret.
+func ModExp(base, exponent *big.Int) *big.Int {
+	return Group14.ModExp(base, exponent)
+}
+
+// BlindedModExp returns base^exponent mod the group #14 modulus computed
+// with blinding. See Group.BlindedModExp.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func BlindedModExp(rand io.Reader, base, exponent *big.Int) (*big.Int, error) {
+	return Group14.BlindedModExp(rand, base, exponent)
+}
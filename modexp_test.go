@@ -0,0 +1,61 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestModExp(t *testing.T) {
+	e := new(big.Int).Add(new(big.Int).SetBytes(golden.privateKey1), new(big.Int).Lsh(one, 258))
+	if y := ModExp(Generator(), e); !bytes.Equal(publicKeyBytes(y), golden.publicKey1) {
+		t.Fatalf("ModExp: wrong public key")
+	}
+	x := new(big.Int).SetBytes(golden.publicKey2)
+	for _, exponent := range []*big.Int{
+		e,
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(12345),
+		new(big.Int).Lsh(one, 256),
+		new(big.Int).Set(modulus),
+		new(big.Int).Lsh(modulus, 10),
+	} {
+		expected := ModExp(x, exponent)
+		y, err := BlindedModExp(rand.Reader, x, exponent)
+		if err != nil {
+			t.Fatalf("%x: %s", exponent, err)
+		}
+		if y.Cmp(expected) != 0 {
+			t.Fatalf("%x: blinded and unblinded results differ", exponent)
+		}
+	}
+	if e.Cmp(new(big.Int).Add(new(big.Int).SetBytes(golden.privateKey1), new(big.Int).Lsh(one, 258))) != 0 {
+		t.Fatalf("BlindedModExp modified exponent")
+	}
+}
+
+func TestBlindedModExpErrors(t *testing.T) {
+	for _, base := range []*big.Int{big.NewInt(0), big.NewInt(-2), modulus} {
+		if _, err := BlindedModExp(rand.Reader, base, one); err != ErrBaseOutOfRange {
+			t.Errorf("base %x: expected %v, got %v", base, ErrBaseOutOfRange, err)
+		}
+	}
+	if _, err := BlindedModExp(rand.Reader, Generator(), big.NewInt(-1)); err != ErrNegativeExponent {
+		t.Errorf("expected %v, got %v", ErrNegativeExponent, err)
+	}
+	defer func() {
+		if recover() != ErrNegativeExponent {
+			t.Errorf("ModExp did not panic on negative exponent")
+		}
+	}()
+	ModExp(Generator(), big.NewInt(-1))
+}
@@ -0,0 +1,31 @@
+//go:build !dhgroup14debug
+
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import "io"
+
+// checkBlinding is a no-op unless the package is built with the
+// dhgroup14debug build tag.
+func checkBlinding([]byte) {}
+
+// checkRand is a no-op unless the package is built with the dhgroup14debug
+// build tag.
+func checkRand(io.Reader) {}
+
+// SetBlindingRepeatHandler sets the function called when the same blinding
+// value is read twice in a row, which indicates a broken random source.
+// Detection is only enabled when the package is built with the
+// dhgroup14debug build tag, for example:
+//
+//	go test -tags dhgroup14debug ./...
+//
+// Without the tag, SetBlindingRepeatHandler has no effect and blinding
+// values are not checked.
+func SetBlindingRepeatHandler(func()) {}
@@ -0,0 +1,91 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"time"
+)
+
+// PeerKey is a validated public key of a peer, for computing shared keys
+// with it repeatedly, for example with a long-term peer public key and
+// different ephemeral private keys. The public key is parsed and validated
+// once, by NewPeerKey, instead of on every call to SharedKey, which saves an
+// exponentiation per call.
+//
+// The parsed public key is available from Int, for protocols that perform
+// further group operations with it.
+//
+// A PeerKey is safe for concurrent use.
+type PeerKey struct {
+	group     *Group
+	publicKey []byte
+	y         *big.Int
+	exp       expFunc
+}
+
+// NewPeerKey validates theirPublicKey, as ValidatePublicKey does, and returns
+// a PeerKey for it. theirPublicKey is copied.
+func NewPeerKey(theirPublicKey []byte) (*PeerKey, error) {
+	return DefaultGroup.NewPeerKey(theirPublicKey)
+}
+
+// NewPeerKey is like the package-level NewPeerKey, but uses group g.
+func (g *Group) NewPeerKey(theirPublicKey []byte) (*PeerKey, error) {
+	if len(theirPublicKey) != g.PublicKeySize {
+		return nil, ErrWrongPublicKeySize
+	}
+	bp := new(big.Int).SetBytes(theirPublicKey)
+	if err := g.validatePublicKey(bp); err != nil {
+		return nil, err
+	}
+	return &PeerKey{
+		group:     g,
+		publicKey: append([]byte(nil), theirPublicKey...),
+		y:         bp,
+		exp:       g.baseExp(bp),
+	}, nil
+}
+
+// Bytes returns a copy of the peer's public key.
+func (p *PeerKey) Bytes() []byte {
+	return append([]byte(nil), p.publicKey...)
+}
+
+// Int returns the validated public key as a number. It returns a new copy on
+// every call, which the caller may modify without affecting p.
+func (p *PeerKey) Int() *big.Int {
+	return new(big.Int).Set(p.y)
+}
+
+// SharedKey returns a shared key between the peer's public key and
+// myPrivateKey, as SharedKey does, without validating the public key again.
+//
+// Random bytes for blinding are read from rand, which must be set to a CSPRNG,
+// such as crypto/rand.Reader. If rand is nil, crypto/rand.Reader is used.
+func (p *PeerKey) SharedKey(rand io.Reader, myPrivateKey []byte) (sharedKey []byte, err error) {
+	return p.SharedKeyContext(context.Background(), rand, myPrivateKey)
+}
+
+// SharedKeyContext is like SharedKey, but stops early with ctx's error if ctx
+// is done. See SharedKeyContext for details.
+func (p *PeerKey) SharedKeyContext(ctx context.Context, rand io.Reader, myPrivateKey []byte) (sharedKey []byte, err error) {
+	g := p.group
+	if err := g.checkPrivateKey(myPrivateKey); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	sharedKey, err = g.blindedModExp(ctx, randReader(rand), p.exp, myPrivateKey)
+	tracerFromContext(ctx).OnSharedKeyComputed(len(sharedKey), time.Since(start), err)
+	return sharedKey, err
+}
@@ -0,0 +1,108 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestPeerKey(t *testing.T) {
+	peer, err := NewPeerKey(golden.publicKey2)
+	if err != nil {
+		t.Fatalf("new peer key: %s", err)
+	}
+	sharedKey, err := peer.SharedKey(rand.Reader, golden.privateKey1)
+	if err != nil {
+		t.Fatalf("shared key: %s", err)
+	}
+	if !bytes.Equal(sharedKey, golden.sharedKey) {
+		t.Fatalf("shared key doesn't match")
+	}
+	if !bytes.Equal(peer.Bytes(), golden.publicKey2) {
+		t.Fatalf("Bytes doesn't return the public key")
+	}
+	y := peer.Int()
+	if y.Cmp(new(big.Int).SetBytes(golden.publicKey2)) != 0 {
+		t.Fatalf("Int doesn't return the public key")
+	}
+	y.SetInt64(1)
+	if peer.Int().Cmp(new(big.Int).SetBytes(golden.publicKey2)) != 0 {
+		t.Fatalf("Int doesn't return a copy")
+	}
+	if sharedKey, err := peer.SharedKey(rand.Reader, golden.privateKey1); err != nil || !bytes.Equal(sharedKey, golden.sharedKey) {
+		t.Fatalf("modifying Int result affected shared key: %v", err)
+	}
+
+	// Different private keys against the same peer key.
+	for i := 0; i < 2; i++ {
+		_, privateKey, err := GenerateKeyPair(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := SharedKey(rand.Reader, golden.publicKey2, privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sharedKey, err := peer.SharedKey(rand.Reader, privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(sharedKey, expected) {
+			t.Fatalf("%d: shared key doesn't match SharedKey", i)
+		}
+	}
+
+	if _, err := peer.SharedKey(rand.Reader, golden.privateKey1[1:]); err != ErrWrongPrivateKeySize {
+		t.Fatalf("expected %v, got %v", ErrWrongPrivateKeySize, err)
+	}
+}
+
+func TestPeerKeyInvalid(t *testing.T) {
+	if _, err := NewPeerKey(golden.publicKey1[1:]); err != ErrWrongPublicKeySize {
+		t.Errorf("short: expected %v, got %v", ErrWrongPublicKeySize, err)
+	}
+	if _, err := NewPeerKey(publicKeyBytes(big.NewInt(1))); err != ErrDegeneratePublicKey {
+		t.Errorf("one: expected %v, got %v", ErrDegeneratePublicKey, err)
+	}
+	if _, err := NewPeerKey(publicKeyBytes(new(big.Int).Sub(modulus, big.NewInt(2)))); err != ErrPublicKeyNotInSubgroup {
+		t.Errorf("non-residue: expected %v, got %v", ErrPublicKeyNotInSubgroup, err)
+	}
+}
+
+func TestPeerKeyCopiesInput(t *testing.T) {
+	publicKey := append([]byte(nil), golden.publicKey2...)
+	peer, err := NewPeerKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey[PublicKeySize-1] ^= 1
+	sharedKey, err := peer.SharedKey(rand.Reader, golden.privateKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sharedKey, golden.sharedKey) {
+		t.Fatalf("peer key affected by modifying input")
+	}
+}
+
+func BenchmarkPeerKeySharedKey(b *testing.B) {
+	peer, err := NewPeerKey(golden.publicKey2)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := peer.SharedKey(rand.Reader, golden.privateKey1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"math/big"
+)
+
+// PublicKeyPEMType is the PEM block type of public keys encoded by
+// MarshalPublicKeyPEM.
+const PublicKeyPEMType = "DH PUBLIC KEY"
+
+// dhKeyAgreement is the PKCS #3 Diffie-Hellman key agreement OID.
+var dhKeyAgreement = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 3, 1}
+
+// OID returns a copy of the object identifier used for group #14 keys in
+// MarshalPKIX and MarshalPKCS8: dhKeyAgreement, 1.2.840.113549.1.3.1, from
+// PKCS #3. RFC 3526 does not assign object identifiers to its groups, so
+// encodings identify the group by its explicit prime and generator in the
+// algorithm parameters, as OpenSSL does, and the OID only names the
+// algorithm. It is the same for every Diffie-Hellman group.
+func OID() asn1.ObjectIdentifier {
+	return append(asn1.ObjectIdentifier(nil), dhKeyAgreement...)
+}
+
+// dhParameters is the PKCS #3 DHParameter structure.
+type dhParameters struct {
+	Prime              *big.Int
+	Base               *big.Int
+	PrivateValueLength int `asn1:"optional"`
+}
+
+type dhAlgorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters dhParameters
+}
+
+// dhPublicKeyInfo is the X.509 SubjectPublicKeyInfo structure for
+// Diffie-Hellman public keys.
+type dhPublicKeyInfo struct {
+	Algorithm dhAlgorithmIdentifier
+	PublicKey asn1.BitString // DER encoding of INTEGER
+}
+
+// dhPrivateKeyInfo is the PKCS #8 PrivateKeyInfo structure for
+// Diffie-Hellman private keys.
+type dhPrivateKeyInfo struct {
+	Version    int
+	Algorithm  dhAlgorithmIdentifier
+	PrivateKey []byte // DER encoding of INTEGER
+}
+
+var (
+	errInvalidEncoding           = errors.New("dhgroup14: invalid public key encoding")
+	errInvalidPrivateKeyEncoding = errors.New("dhgroup14: invalid private key encoding")
+)
+
+// group14Algorithm returns the algorithm identifier of group #14 keys.
+func group14Algorithm() dhAlgorithmIdentifier {
+	return dhAlgorithmIdentifier{
+		Algorithm: dhKeyAgreement,
+		Parameters: dhParameters{
+			Prime: Group14.Modulus,
+			Base:  Group14.Generator,
+		},
+	}
+}
+
+// isGroup14Algorithm reports whether alg is dhKeyAgreement with group #14
+// parameters.
+func isGroup14Algorithm(alg dhAlgorithmIdentifier) bool {
+	params := alg.Parameters
+	return alg.Algorithm.Equal(dhKeyAgreement) &&
+		params.Prime.Cmp(Group14.Modulus) == 0 &&
+		params.Base.Cmp(Group14.Generator) == 0
+}
+
+// MarshalPKIX returns the DER encoding of publicKey as an X.509
+// SubjectPublicKeyInfo with the PKCS #3 dhKeyAgreement algorithm and
+// group #14 parameters (prime and generator), as produced by OpenSSL.
+func MarshalPKIX(publicKey []byte) ([]byte, error) {
+	if len(publicKey) != PublicKeySize {
+		return nil, ErrWrongPublicKeySize
+	}
+	y, err := asn1.Marshal(new(big.Int).SetBytes(publicKey))
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(dhPublicKeyInfo{
+		Algorithm: group14Algorithm(),
+		PublicKey: asn1.BitString{Bytes: y, BitLength: 8 * len(y)},
+	})
+}
+
+// ParsePKIX parses a DER-encoded SubjectPublicKeyInfo, as produced by
+// MarshalPKIX or OpenSSL, and returns the PublicKeySize-byte public key.
+// It returns an error if the encoded parameters are not of group #14.
+func ParsePKIX(der []byte) ([]byte, error) {
+	var info dhPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil || len(rest) != 0 {
+		return nil, errInvalidEncoding
+	}
+	if !isGroup14Algorithm(info.Algorithm) {
+		return nil, errors.New("dhgroup14: public key is not of group 14")
+	}
+	if info.PublicKey.BitLength%8 != 0 {
+		return nil, errInvalidEncoding
+	}
+	y := new(big.Int)
+	rest, err = asn1.Unmarshal(info.PublicKey.Bytes, &y)
+	if err != nil || len(rest) != 0 {
+		return nil, errInvalidEncoding
+	}
+	if y.Sign() <= 0 {
+		return nil, errInvalidEncoding
+	}
+	if y.Cmp(Group14.Modulus) >= 0 {
+		return nil, ErrPublicKeyOutOfRange
+	}
+	publicKey := make([]byte, PublicKeySize)
+	return y.FillBytes(publicKey), nil
+}
+
+// MarshalPublicKeyPEM returns publicKey encoded as a PEM block of type
+// PublicKeyPEMType, which contains the output of MarshalPKIX.
+func MarshalPublicKeyPEM(publicKey []byte) ([]byte, error) {
+	der, err := MarshalPKIX(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PublicKeyPEMType, Bytes: der}), nil
+}
+
+// ParsePublicKeyPEM parses the first PEM block in data, which must be of type
+// PublicKeyPEMType, and returns the public key. It returns an error if the
+// encoded parameters are not of group #14.
+func ParsePublicKeyPEM(data []byte) ([]byte, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != PublicKeyPEMType {
+		return nil, errors.New("dhgroup14: no DH PUBLIC KEY PEM block")
+	}
+	return ParsePKIX(block.Bytes)
+}
+
+// MarshalPKCS8 returns the DER encoding of privateKey as a PKCS #8
+// PrivateKeyInfo with the PKCS #3 dhKeyAgreement algorithm and group #14
+// parameters. The encoded private value is the actual exponent,
+// 2^258 + privateKey, so that other implementations, such as OpenSSL,
+// derive the same public key from it.
+//
+// The result contains the private key and should be zeroized after use.
+func MarshalPKCS8(privateKey []byte) ([]byte, error) {
+	if err := Group14.checkPrivateKey(privateKey); err != nil {
+		return nil, err
+	}
+	x := new(big.Int).SetBytes(privateKey)
+	defer wipeInt(x)
+	x.Add(x, Group14.exponentOffset())
+	xDER, err := asn1.Marshal(x)
+	if err != nil {
+		return nil, err
+	}
+	defer Zeroize(xDER)
+	return asn1.Marshal(dhPrivateKeyInfo{
+		Algorithm:  group14Algorithm(),
+		PrivateKey: xDER,
+	})
+}
+
+// ParsePKCS8 parses a DER-encoded PKCS #8 PrivateKeyInfo, as produced by
+// MarshalPKCS8, and returns the PrivateKeySize-byte private key. It returns
+// an error if the encoded parameters are not of group #14, or if the private
+// value is not 2^258 + k for a valid PrivateKeySize-byte private key k.
+func ParsePKCS8(der []byte) ([]byte, error) {
+	var info dhPrivateKeyInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil || len(rest) != 0 || info.Version != 0 {
+		return nil, errInvalidPrivateKeyEncoding
+	}
+	defer Zeroize(info.PrivateKey)
+	if !isGroup14Algorithm(info.Algorithm) {
+		return nil, errors.New("dhgroup14: private key is not of group 14")
+	}
+	x := new(big.Int)
+	defer wipeInt(x)
+	rest, err = asn1.Unmarshal(info.PrivateKey, &x)
+	if err != nil || len(rest) != 0 {
+		return nil, errInvalidPrivateKeyEncoding
+	}
+	x.Sub(x, Group14.exponentOffset())
+	if x.Sign() < 0 || x.BitLen() > 8*PrivateKeySize {
+		return nil, ErrWrongPrivateKeySize
+	}
+	privateKey := x.FillBytes(make([]byte, PrivateKeySize))
+	if err := Group14.checkPrivateKey(privateKey); err != nil {
+		Zeroize(privateKey)
+		return nil, err
+	}
+	return privateKey, nil
+}
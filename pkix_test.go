@@ -0,0 +1,233 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// Public key generated by OpenSSL 3.0 with
+//
+//	openssl genpkey -algorithm DH -pkeyopt group:modp_2048 -out dh.pem
+//	openssl pkey -in dh.pem -pubout -outform DER
+const opensslPublicKeyDER = "" +
+	"308202243082011706092a864886f70d010301308201080282010100ffffffff" +
+	"ffffffffc90fdaa22168c234c4c6628b80dc1cd129024e088a67cc74020bbea6" +
+	"3b139b22514a08798e3404ddef9519b3cd3a431b302b0a6df25f14374fe1356d" +
+	"6d51c245e485b576625e7ec6f44c42e9a637ed6b0bff5cb6f406b7edee386bfb" +
+	"5a899fa5ae9f24117c4b1fe649286651ece45b3dc2007cb8a163bf0598da4836" +
+	"1c55d39a69163fa8fd24cf5f83655d23dca3ad961c62f356208552bb9ed52907" +
+	"7096966d670c354e4abc9804f1746c08ca18217c32905e462e36ce3be39e772c" +
+	"180e86039b2783a2ec07a28fb5c55df06f4c52c9de2bcbf6955817183995497c" +
+	"ea956ae515d2261898fa051015728e5a8aacaa68ffffffffffffffff02010203" +
+	"82010500028201005cf67f70d7fe4e16bc3a057783ce345129e8f3f9e1d0f3e9" +
+	"1b5289c85012dc23bab410696230fadd136f5ef8e62600074fbb02f03629a1dc" +
+	"0aed1638729d4d3a3b5a2ae37de2266adc2b7af7041a518940e384ffbbe86cf5" +
+	"93710f6617f1028ce04d3d4d86f5ced566b6bb88e6b94724cb951ba13cd73698" +
+	"5f8a98ba7b005edc47a582159b5148bce759df3d6f807a8b3ad464f2151909a7" +
+	"c1a271b3128f2b9f5abbedadf5461dd93d6b016e425528be475f808e19817de7" +
+	"8f5a6ac22f8252a6fe052a5859048965ffadbcd35f3d1358101f5faea408a897" +
+	"b7ed4415cf3c72b1a813edaa84d75915e9b5b093b7e552e203680a60dc0cebfa" +
+	"c3921b97239fff0b"
+
+func TestOID(t *testing.T) {
+	oid := OID()
+	if oid.String() != "1.2.840.113549.1.3.1" {
+		t.Fatalf("unexpected OID %s", oid)
+	}
+	oid[0] = 2
+	if !OID().Equal(dhKeyAgreement) {
+		t.Fatalf("OID doesn't return a copy")
+	}
+}
+
+func TestParsePKIXOpenSSL(t *testing.T) {
+	der, err := hex.DecodeString(opensslPublicKeyDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := ParsePKIX(der)
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if err := ValidatePublicKey(publicKey); err != nil {
+		t.Fatalf("parsed invalid public key: %s", err)
+	}
+	marshaled, err := MarshalPKIX(publicKey)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	if !bytes.Equal(marshaled, der) {
+		t.Fatalf("marshaled DER differs from OpenSSL:\n%x\n%x", marshaled, der)
+	}
+}
+
+func TestPKIXPrivateValueLength(t *testing.T) {
+	y, _ := asn1.Marshal(new(big.Int).SetBytes(golden.publicKey1))
+	info := dhPublicKeyInfo{
+		Algorithm: dhAlgorithmIdentifier{
+			Algorithm: dhKeyAgreement,
+			Parameters: dhParameters{
+				Prime:              Group14.Modulus,
+				Base:               Group14.Generator,
+				PrivateValueLength: 256,
+			},
+		},
+		PublicKey: asn1.BitString{Bytes: y, BitLength: 8 * len(y)},
+	}
+	der, err := asn1.Marshal(info)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	publicKey, err := ParsePKIX(der)
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if !bytes.Equal(publicKey, golden.publicKey1) {
+		t.Fatalf("parsed wrong public key: %x", publicKey)
+	}
+}
+
+func TestPublicKeyPEMRoundTrip(t *testing.T) {
+	data, err := MarshalPublicKeyPEM(golden.publicKey1)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	if !bytes.HasPrefix(data, []byte("-----BEGIN DH PUBLIC KEY-----\n")) {
+		t.Fatalf("wrong PEM block:\n%s", data)
+	}
+	publicKey, err := ParsePublicKeyPEM(data)
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if !bytes.Equal(publicKey, golden.publicKey1) {
+		t.Fatalf("parsed wrong public key: %x", publicKey)
+	}
+	// Public key with leading zero byte.
+	publicKey = publicKeyBytes(big.NewInt(4))
+	data, err = MarshalPublicKeyPEM(publicKey)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	parsed, err := ParsePublicKeyPEM(data)
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if !bytes.Equal(parsed, publicKey) {
+		t.Fatalf("parsed wrong public key: %x", parsed)
+	}
+}
+
+func TestParsePublicKeyPEMErrors(t *testing.T) {
+	if _, err := MarshalPublicKeyPEM(golden.publicKey1[1:]); err != ErrWrongPublicKeySize {
+		t.Errorf("marshal short key: expected %v, got %v", ErrWrongPublicKeySize, err)
+	}
+	der, err := MarshalPKIX(golden.publicKey1)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	wrongType := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	if _, err := ParsePublicKeyPEM(wrongType); err == nil {
+		t.Errorf("accepted wrong PEM type")
+	}
+	if _, err := ParsePublicKeyPEM(golden.publicKey1); err == nil {
+		t.Errorf("accepted non-PEM input")
+	}
+	trailing := pem.EncodeToMemory(&pem.Block{Type: PublicKeyPEMType, Bytes: append(der, 0)})
+	if _, err := ParsePublicKeyPEM(trailing); err == nil {
+		t.Errorf("accepted trailing data")
+	}
+	// Group 15 parameters.
+	info := dhPublicKeyInfo{
+		Algorithm: dhAlgorithmIdentifier{
+			Algorithm:  dhKeyAgreement,
+			Parameters: dhParameters{Prime: Group15.Modulus, Base: Group15.Generator},
+		},
+	}
+	info.PublicKey.Bytes, _ = asn1.Marshal(big.NewInt(4))
+	info.PublicKey.BitLength = 8 * len(info.PublicKey.Bytes)
+	der, err = asn1.Marshal(info)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	if _, err := ParsePKIX(der); err == nil {
+		t.Errorf("accepted group 15 parameters")
+	}
+}
+
+func TestPKCS8RoundTrip(t *testing.T) {
+	der, err := MarshalPKCS8(golden.privateKey1)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	// The private value is an INTEGER 2^258 + privateKey in an OCTET
+	// STRING. OpenSSL 3.0 derives golden.publicKey1 from this encoding:
+	//
+	//	openssl pkey -inform DER -pubout -outform DER
+	suffix := "0423022104" + hex.EncodeToString(golden.privateKey1)
+	if got := hex.EncodeToString(der); !strings.HasSuffix(got, suffix) {
+		t.Fatalf("unexpected encoding %s", got)
+	}
+	privateKey, err := ParsePKCS8(der)
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if !bytes.Equal(privateKey, golden.privateKey1) {
+		t.Fatalf("parsed wrong private key")
+	}
+	if _, err := MarshalPKCS8(golden.privateKey1[1:]); err != ErrWrongPrivateKeySize {
+		t.Fatalf("short key: expected %v, got %v", ErrWrongPrivateKeySize, err)
+	}
+}
+
+func TestParsePKCS8Errors(t *testing.T) {
+	marshal := func(version int, params dhParameters, x *big.Int) []byte {
+		xDER, err := asn1.Marshal(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := asn1.Marshal(dhPrivateKeyInfo{
+			Version:    version,
+			Algorithm:  dhAlgorithmIdentifier{Algorithm: dhKeyAgreement, Parameters: params},
+			PrivateKey: xDER,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return der
+	}
+	group14 := dhParameters{Prime: modulus, Base: big.NewInt(2)}
+	offset := new(big.Int).Lsh(one, 258)
+	valid := new(big.Int).Add(offset, new(big.Int).SetBytes(golden.privateKey1))
+	if _, err := ParsePKCS8(marshal(0, group14, valid)); err != nil {
+		t.Fatalf("valid key: %s", err)
+	}
+	bad := []struct {
+		name string
+		der  []byte
+	}{
+		{"garbage", []byte{0x30, 0x00}},
+		{"trailing data", append(marshal(0, group14, valid), 0)},
+		{"version", marshal(1, group14, valid)},
+		{"group", marshal(0, dhParameters{Prime: FFDHE2048.Modulus, Base: big.NewInt(2)}, valid)},
+		{"no offset", marshal(0, group14, new(big.Int).SetBytes(golden.privateKey1))},
+		{"too large", marshal(0, group14, new(big.Int).Lsh(one, 259))},
+		{"zero key", marshal(0, group14, offset)},
+	}
+	for _, v := range bad {
+		if _, err := ParsePKCS8(v.der); err == nil {
+			t.Errorf("%s: expected error", v.name)
+		}
+	}
+}
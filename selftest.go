@@ -0,0 +1,126 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// selfTestVector is a known-answer test vector generated by Colin Percival's
+// implementation from spiped. Public and shared keys are represented by
+// their SHA-256 hashes.
+var selfTestVector = struct {
+	privateKey1    []byte
+	privateKey2    []byte
+	publicKey1Hash [sha256.Size]byte
+	publicKey2Hash [sha256.Size]byte
+	sharedKeyHash  [sha256.Size]byte
+}{
+	privateKey1: []byte{
+		0x07, 0xcd, 0xed, 0xf7, 0x97, 0x7e, 0xf9, 0x0e, 0x2f, 0x9a,
+		0x69, 0x4c, 0x6d, 0xdb, 0xca, 0xee, 0x83, 0x1e, 0x37, 0x65,
+		0x9d, 0x6e, 0xa2, 0x97, 0x15, 0x72, 0xdb, 0x38, 0xdc, 0x06,
+		0x36, 0xd4,
+	},
+	privateKey2: []byte{
+		0x82, 0x30, 0x8e, 0xbe, 0x0a, 0x6c, 0xed, 0x6c, 0xeb, 0xec,
+		0x65, 0x72, 0x84, 0x47, 0xaf, 0xa2, 0x6a, 0x89, 0xe3, 0x6f,
+		0x68, 0xe8, 0x7d, 0xd9, 0xa4, 0xa3, 0x7b, 0x4b, 0xb8, 0xf2,
+		0x73, 0x51,
+	},
+	publicKey1Hash: [sha256.Size]byte{
+		0x33, 0x3e, 0x93, 0xeb, 0x6c, 0xc2, 0x0e, 0x9d, 0x20, 0x1f,
+		0x4a, 0xb9, 0xcc, 0xa2, 0xea, 0x0f, 0x14, 0x14, 0x8d, 0x12,
+		0x90, 0x72, 0xb9, 0xb5, 0x64, 0xa2, 0xaf, 0x4b, 0x4c, 0x1f,
+		0x64, 0x6b,
+	},
+	publicKey2Hash: [sha256.Size]byte{
+		0x02, 0x95, 0x8a, 0xff, 0x0d, 0x75, 0x86, 0xec, 0xd5, 0x9d,
+		0xad, 0xf9, 0x67, 0xdd, 0xae, 0x95, 0x43, 0xc4, 0xcc, 0x8e,
+		0xf4, 0x2f, 0x33, 0x44, 0x83, 0xb8, 0xb1, 0x89, 0xc7, 0xd6,
+		0xd7, 0x89,
+	},
+	sharedKeyHash: [sha256.Size]byte{
+		0xbd, 0x36, 0x6a, 0x8f, 0x81, 0xf0, 0x06, 0x9f, 0xb6, 0x49,
+		0xeb, 0x8f, 0x1c, 0x81, 0xd7, 0x56, 0xbf, 0x32, 0x79, 0x1e,
+		0x6e, 0x9b, 0xbc, 0x27, 0x52, 0x59, 0x11, 0x7f, 0x5d, 0xdc,
+		0x29, 0xb8,
+	},
+}
+
+// modulusHash is the SHA-256 hash of the 256-byte big-endian encoding of
+// the RFC 3526 group #14 prime.
+var modulusHash = [sha256.Size]byte{
+	0xd6, 0x64, 0x36, 0xf7, 0x9b, 0xbd, 0x6b, 0x2e, 0x38, 0xc0,
+	0xff, 0xbd, 0x07, 0x9b, 0xe9, 0x04, 0xd2, 0x64, 0x14, 0x15,
+	0xe2, 0xe6, 0x71, 0x40, 0xe0, 0x94, 0x48, 0xbe, 0x9a, 0x60,
+	0x89, 0x0e,
+}
+
+var (
+	errSelfTest  = errors.New("dhgroup14: self-test failed")
+	errSelfCheck = errors.New("dhgroup14: group #14 parameters are corrupted")
+)
+
+// SelfCheck verifies that the embedded group #14 parameters have not been
+// modified: the SHA-256 hash of the modulus must match the hash of the
+// RFC 3526 prime, and the generator must be 2. It is much cheaper than
+// SelfTest and does no exponentiation.
+func SelfCheck() error {
+	g := Group14
+	if g.Modulus.Sign() <= 0 || g.Modulus.BitLen() != 8*PublicKeySize {
+		return errSelfCheck
+	}
+	if sha256.Sum256(g.Modulus.FillBytes(make([]byte, PublicKeySize))) != modulusHash {
+		return errSelfCheck
+	}
+	if g.Generator.Cmp(big.NewInt(2)) != 0 || g.PrivateKeySize != PrivateKeySize || g.PublicKeySize != PublicKeySize {
+		return errSelfCheck
+	}
+	if g.ExponentOffset != nil && g.ExponentOffset.Cmp(new(big.Int).Lsh(one, 258)) != 0 {
+		return errSelfCheck
+	}
+	return nil
+}
+
+// SelfTest performs a known-answer test of key agreement in group #14
+// without blinding and without randomness. It returns an error if any
+// computed key differs from the expected value, which indicates a broken
+// implementation. SelfTest also performs SelfCheck.
+func SelfTest() error {
+	if err := SelfCheck(); err != nil {
+		return err
+	}
+	v := &selfTestVector
+	publicKey1, err := Group14.GeneratePublicKeyDeterministic(v.privateKey1)
+	if err != nil {
+		return err
+	}
+	publicKey2, err := Group14.GeneratePublicKeyDeterministic(v.privateKey2)
+	if err != nil {
+		return err
+	}
+	if sha256.Sum256(publicKey1) != v.publicKey1Hash || sha256.Sum256(publicKey2) != v.publicKey2Hash {
+		return errSelfTest
+	}
+	for _, p := range [][2][]byte{{publicKey1, v.privateKey2}, {publicKey2, v.privateKey1}} {
+		if err := Group14.ValidatePublicKey(p[0]); err != nil {
+			return err
+		}
+		sharedKey, err := Group14.modExp(Group14.baseExp(new(big.Int).SetBytes(p[0])), p[1])
+		if err != nil {
+			return err
+		}
+		if sha256.Sum256(sharedKey) != v.sharedKeyHash {
+			return errSelfTest
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Fatalf("self-test: %s", err)
+	}
+	saved := selfTestVector.sharedKeyHash
+	defer func() { selfTestVector.sharedKeyHash = saved }()
+	selfTestVector.sharedKeyHash[0] ^= 1
+	if err := SelfTest(); err != errSelfTest {
+		t.Fatalf("expected %v, got %v", errSelfTest, err)
+	}
+}
+
+func TestSelfCheck(t *testing.T) {
+	if err := SelfCheck(); err != nil {
+		t.Fatalf("self-check: %s", err)
+	}
+	saved := Group14
+	defer func() { Group14 = saved }()
+	for _, g := range []*Group{
+		newGroup("", "", Group15.Modulus),
+		newGroup("", "", new(big.Int).Add(saved.Modulus, big.NewInt(2))),
+		{Modulus: saved.Modulus, Generator: big.NewInt(5), PrivateKeySize: PrivateKeySize, PublicKeySize: PublicKeySize},
+	} {
+		Group14 = g
+		if err := SelfCheck(); err != errSelfCheck {
+			t.Errorf("expected %v, got %v", errSelfCheck, err)
+		}
+		if err := SelfTest(); err != errSelfCheck {
+			t.Errorf("self-test: expected %v, got %v", errSelfCheck, err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestSharedKeyTiming is a heuristic regression test for gross timing
+// leaks that depend on the private key, such as a switch to a variable-time
+// exponentiation path. It compares the median SharedKey latency for random
+// private keys with very low and very high Hamming weight, which a
+// square-and-multiply implementation would tell apart. It is not a proof of
+// constant-time behavior.
+//
+// It cannot detect that blinding was removed: math/big uses fixed-window
+// exponentiation, which takes the same time for both classes with or
+// without blinding. TestDeterministicBlinding, TestGenerateKeyPairWithBlinding
+// and TestRandomnessReadErrors guard against losing blinding instead.
+//
+// Timing measurements are unreliable on loaded machines, so the test only
+// runs if DHGROUP14_TIMING=1 is set, and never in short mode.
+func TestSharedKeyTiming(t *testing.T) {
+	if testing.Short() || os.Getenv("DHGROUP14_TIMING") != "1" {
+		t.Skip("skipping timing test; set DHGROUP14_TIMING=1 to run it")
+	}
+	const (
+		rounds  = 25
+		flipped = 8 // bits set in low-weight keys, cleared in high-weight keys
+	)
+	// randomKey returns a private key with all bytes set to fill, except for
+	// up to flipped random bits, which are inverted.
+	randomKey := func(fill byte) []byte {
+		privateKey := bytes.Repeat([]byte{fill}, PrivateKeySize)
+		var pos [flipped]byte
+		if _, err := rand.Read(pos[:]); err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range pos {
+			if fill == 0 {
+				privateKey[p%PrivateKeySize] |= 1 << (p / PrivateKeySize % 8)
+			} else {
+				privateKey[p%PrivateKeySize] &^= 1 << (p / PrivateKeySize % 8)
+			}
+		}
+		return privateKey
+	}
+	measure := func(privateKey []byte) time.Duration {
+		start := time.Now()
+		if _, err := SharedKey(rand.Reader, golden.publicKey1, privateKey); err != nil {
+			t.Fatal(err)
+		}
+		return time.Since(start)
+	}
+	// Warm up, then interleave measurements to spread out noise.
+	measure(randomKey(0))
+	measure(randomKey(0xff))
+	var lowTimes, highTimes []time.Duration
+	for i := 0; i < rounds; i++ {
+		lowTimes = append(lowTimes, measure(randomKey(0)))
+		highTimes = append(highTimes, measure(randomKey(0xff)))
+	}
+	median := func(d []time.Duration) time.Duration {
+		slices.Sort(d)
+		return d[len(d)/2]
+	}
+	lowMedian, highMedian := median(lowTimes), median(highTimes)
+	// Allow 20% difference between medians.
+	if diff := lowMedian - highMedian; diff > highMedian/5 || -diff > lowMedian/5 {
+		t.Errorf("median latency differs by class: low weight %v, high weight %v", lowMedian, highMedian)
+	}
+	t.Logf("median latency: low weight %v, high weight %v", lowMedian, highMedian)
+}
@@ -0,0 +1,55 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"context"
+	"time"
+)
+
+// Tracer receives non-secret metadata about key exchange operations, such
+// as key sizes and timings, for debugging interoperability issues. Tracer
+// methods never receive key material.
+//
+// A Tracer is attached to a context with WithTracer and is called by the
+// context-aware functions, such as GenerateKeyPairContext and
+// SharedKeyContext. Methods may be called concurrently.
+type Tracer interface {
+	// OnPrivateKeyGenerated is called after a private key of size bytes
+	// has been read from the random source.
+	OnPrivateKeyGenerated(size int)
+
+	// OnPublicKeyComputed is called after public key computation. On
+	// success, size is the public key size in bytes and err is nil.
+	OnPublicKeyComputed(size int, elapsed time.Duration, err error)
+
+	// OnSharedKeyComputed is called after shared key computation. On
+	// success, size is the shared key size in bytes and err is nil.
+	OnSharedKeyComputed(size int, elapsed time.Duration, err error)
+}
+
+type tracerKey struct{}
+
+// WithTracer returns a copy of ctx that carries t.
+func WithTracer(ctx context.Context, t Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// tracerFromContext returns the Tracer carried by ctx, or a no-op Tracer.
+func tracerFromContext(ctx context.Context) Tracer {
+	if t, ok := ctx.Value(tracerKey{}).(Tracer); ok && t != nil {
+		return t
+	}
+	return nopTracer{}
+}
+
+type nopTracer struct{}
+
+func (nopTracer) OnPrivateKeyGenerated(int)                     {}
+func (nopTracer) OnPublicKeyComputed(int, time.Duration, error) {}
+func (nopTracer) OnSharedKeyComputed(int, time.Duration, error) {}
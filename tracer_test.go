@@ -0,0 +1,81 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingTracer struct {
+	mu     sync.Mutex
+	events []string
+	sizes  []int
+}
+
+func (t *recordingTracer) record(event string, size int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+	t.sizes = append(t.sizes, size)
+}
+
+func (t *recordingTracer) OnPrivateKeyGenerated(size int) {
+	t.record("private", size)
+}
+
+func (t *recordingTracer) OnPublicKeyComputed(size int, elapsed time.Duration, err error) {
+	if err != nil || elapsed <= 0 {
+		size = -1
+	}
+	t.record("public", size)
+}
+
+func (t *recordingTracer) OnSharedKeyComputed(size int, elapsed time.Duration, err error) {
+	if err != nil {
+		size = -1
+	}
+	t.record("shared", size)
+}
+
+func TestTracer(t *testing.T) {
+	tracer := new(recordingTracer)
+	ctx := WithTracer(context.Background(), tracer)
+	publicKey, privateKey, err := GenerateKeyPairContext(ctx, rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key pair: %s", err)
+	}
+	if _, err := SharedKeyContext(ctx, rand.Reader, publicKey, privateKey); err != nil {
+		t.Fatalf("compute shared key: %s", err)
+	}
+	if _, err := SharedKeyContext(ctx, errorReader{}, publicKey, privateKey); err == nil {
+		t.Fatalf("expected error from failing reader")
+	}
+	events := []string{"private", "public", "shared", "shared"}
+	sizes := []int{PrivateKeySize, PublicKeySize, SharedKeySize, -1}
+	if len(tracer.events) != len(events) {
+		t.Fatalf("expected events %v, got %v", events, tracer.events)
+	}
+	for i := range events {
+		if tracer.events[i] != events[i] || tracer.sizes[i] != sizes[i] {
+			t.Errorf("%d: expected %s(%d), got %s(%d)", i, events[i], sizes[i], tracer.events[i], tracer.sizes[i])
+		}
+	}
+}
+
+func TestNoTracer(t *testing.T) {
+	if _, ok := tracerFromContext(context.Background()).(nopTracer); !ok {
+		t.Fatalf("expected no-op tracer for context without tracer")
+	}
+	if _, ok := tracerFromContext(WithTracer(context.Background(), nil)).(nopTracer); !ok {
+		t.Fatalf("expected no-op tracer for nil tracer")
+	}
+}
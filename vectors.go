@@ -0,0 +1,542 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+// TestVector is a group #14 key agreement test vector.
+type TestVector struct {
+	PrivateKeyA, PrivateKeyB []byte // PrivateKeySize bytes
+	PublicKeyA, PublicKeyB   []byte // PublicKeySize bytes
+	SharedKey                []byte // SharedKeySize bytes
+}
+
+// numTestVectors is the number of vectors returned by TestVectors.
+const numTestVectors = 5
+
+// TestVectors returns fixed group #14 test vectors for verifying other
+// implementations. All values are big-endian byte strings.
+//
+// The first vector uses the private keys of the spiped test vector. For
+// vectors i = 1, ..., 4, PrivateKeyA and PrivateKeyB are the SHA-256 hashes
+// of the ASCII strings "dhgroup14 test vector <i> A" and
+// "dhgroup14 test vector <i> B". In all vectors:
+//
+//	PublicKeyA = 2^(2^258 + PrivateKeyA) mod p
+//	PublicKeyB = 2^(2^258 + PrivateKeyB) mod p
+//	SharedKey  = PublicKeyA^(2^258 + PrivateKeyB) mod p
+//	           = PublicKeyB^(2^258 + PrivateKeyA) mod p
+//
+// where p is the RFC 3526 group #14 prime. The values are embedded in the
+// package, not computed by it. Each call returns new slices.
+func TestVectors() []TestVector {
+	vectors := make([]TestVector, len(testVectors))
+	for i, v := range testVectors {
+		vectors[i] = TestVector{
+			PrivateKeyA: append([]byte(nil), v.PrivateKeyA...),
+			PrivateKeyB: append([]byte(nil), v.PrivateKeyB...),
+			PublicKeyA:  append([]byte(nil), v.PublicKeyA...),
+			PublicKeyB:  append([]byte(nil), v.PublicKeyB...),
+			SharedKey:   append([]byte(nil), v.SharedKey...),
+		}
+	}
+	return vectors
+}
+
+// testVectors holds the vectors returned by TestVectors, computed
+// independently of this package.
+var testVectors = [numTestVectors]TestVector{
+	{
+		PrivateKeyA: []byte{
+			0x07, 0xcd, 0xed, 0xf7, 0x97, 0x7e, 0xf9, 0x0e, 0x2f, 0x9a,
+			0x69, 0x4c, 0x6d, 0xdb, 0xca, 0xee, 0x83, 0x1e, 0x37, 0x65,
+			0x9d, 0x6e, 0xa2, 0x97, 0x15, 0x72, 0xdb, 0x38, 0xdc, 0x06,
+			0x36, 0xd4,
+		},
+		PrivateKeyB: []byte{
+			0x82, 0x30, 0x8e, 0xbe, 0x0a, 0x6c, 0xed, 0x6c, 0xeb, 0xec,
+			0x65, 0x72, 0x84, 0x47, 0xaf, 0xa2, 0x6a, 0x89, 0xe3, 0x6f,
+			0x68, 0xe8, 0x7d, 0xd9, 0xa4, 0xa3, 0x7b, 0x4b, 0xb8, 0xf2,
+			0x73, 0x51,
+		},
+		PublicKeyA: []byte{
+			0x09, 0xb5, 0x67, 0xcb, 0x98, 0x51, 0xdc, 0xb2, 0xab, 0xfd,
+			0x6f, 0x83, 0x5d, 0xfc, 0x63, 0xe8, 0x82, 0x82, 0x9c, 0x33,
+			0x9f, 0x18, 0x03, 0x31, 0x42, 0xd7, 0x26, 0x71, 0x71, 0x6e,
+			0x68, 0xb7, 0x02, 0xc4, 0xb2, 0xa1, 0x5f, 0xc1, 0xff, 0x23,
+			0xcc, 0x66, 0x31, 0x90, 0xca, 0x9a, 0xf5, 0x96, 0xe0, 0x65,
+			0x62, 0xf0, 0xc6, 0x16, 0x2b, 0x15, 0x5b, 0x6e, 0xa6, 0x8c,
+			0xae, 0x19, 0x33, 0x8c, 0x32, 0x99, 0x06, 0x53, 0xc0, 0xac,
+			0x8b, 0x51, 0x0a, 0xf4, 0x7c, 0x04, 0x1b, 0xf5, 0xe7, 0x7e,
+			0xe3, 0x7f, 0xac, 0x7f, 0xa6, 0xd8, 0x74, 0xad, 0x74, 0xcf,
+			0xca, 0x9c, 0xdd, 0x1f, 0x0c, 0xfb, 0xbe, 0xfa, 0x84, 0x49,
+			0xa7, 0x8c, 0x1d, 0x9a, 0x64, 0xca, 0x96, 0x5f, 0xcb, 0x13,
+			0x76, 0xd3, 0x0a, 0x4f, 0xa1, 0x50, 0xe0, 0x22, 0xfd, 0x77,
+			0xa6, 0x6b, 0x0d, 0x5c, 0x83, 0x24, 0xf9, 0xb0, 0xeb, 0xfc,
+			0x0a, 0x45, 0x25, 0x0f, 0x84, 0x05, 0x44, 0x18, 0xd0, 0x50,
+			0x8d, 0xea, 0x69, 0xf0, 0x42, 0x36, 0xda, 0x43, 0xea, 0xc9,
+			0xcc, 0xf9, 0xd7, 0x49, 0x1c, 0x43, 0x4b, 0x21, 0xc7, 0x81,
+			0x62, 0x1c, 0x6c, 0x2a, 0xa2, 0xfe, 0x84, 0x60, 0x42, 0x42,
+			0x35, 0xb0, 0x6c, 0x58, 0x23, 0x89, 0xae, 0x36, 0x5e, 0xb4,
+			0x3b, 0x2a, 0xed, 0x20, 0xdc, 0x01, 0xed, 0x56, 0x36, 0x42,
+			0x3a, 0x8f, 0xd1, 0x3f, 0xef, 0x4b, 0x04, 0x32, 0xc0, 0x60,
+			0x18, 0x25, 0x3c, 0xc2, 0x22, 0x27, 0x50, 0xd2, 0x94, 0x4b,
+			0xe1, 0xcd, 0x5d, 0x22, 0xe0, 0x0d, 0x4f, 0xa0, 0x24, 0x12,
+			0x2e, 0xce, 0x4a, 0xef, 0xa1, 0xd0, 0x79, 0xc7, 0xf6, 0x38,
+			0x11, 0x44, 0xaf, 0xee, 0xe8, 0x1f, 0xb9, 0x7d, 0xe5, 0x6b,
+			0x91, 0x3c, 0x5a, 0xff, 0x5b, 0xe4, 0xa8, 0xc6, 0xc6, 0x7f,
+			0x1b, 0xdb, 0xe8, 0xb4, 0x22, 0x19,
+		},
+		PublicKeyB: []byte{
+			0x12, 0x38, 0xda, 0x6c, 0x9b, 0x6c, 0x75, 0xda, 0x55, 0x0e,
+			0x20, 0x83, 0x2f, 0xb2, 0xaa, 0xf3, 0xa9, 0x8b, 0x1b, 0x7d,
+			0xbb, 0x5f, 0x8c, 0x4d, 0xe5, 0x69, 0xb1, 0xc6, 0x61, 0x44,
+			0xe7, 0xb3, 0xf8, 0x68, 0x75, 0xb0, 0x90, 0x5c, 0x7b, 0xe6,
+			0x60, 0x03, 0x0d, 0xdd, 0x51, 0x6a, 0x7d, 0x1f, 0x3d, 0x43,
+			0x55, 0xe7, 0xc3, 0x87, 0x76, 0xc6, 0xcf, 0xff, 0x05, 0x2d,
+			0xf7, 0xcf, 0xd4, 0x8d, 0x9b, 0x34, 0xf7, 0x47, 0xe3, 0x57,
+			0xb5, 0x1c, 0x2b, 0x6d, 0x8e, 0x19, 0xce, 0x4a, 0x49, 0x68,
+			0x1e, 0x79, 0xbc, 0x6f, 0xcc, 0xea, 0x42, 0xdf, 0xa6, 0x3f,
+			0x5f, 0xfa, 0x05, 0xa2, 0x39, 0x4f, 0x98, 0xf1, 0xd8, 0xf8,
+			0x95, 0x8d, 0xe1, 0xc3, 0xaa, 0xe3, 0xcb, 0xe0, 0xe9, 0xe9,
+			0x11, 0x2f, 0xf1, 0x4f, 0xfc, 0xd7, 0x3f, 0x57, 0x7d, 0xe5,
+			0x91, 0xa1, 0x2f, 0x3e, 0x95, 0xba, 0x99, 0xd2, 0x25, 0xab,
+			0xf6, 0xb6, 0xce, 0xf6, 0x1a, 0x2a, 0xa1, 0x1c, 0x19, 0x56,
+			0x2d, 0xf9, 0x65, 0xbb, 0x96, 0x70, 0xc4, 0xa7, 0x40, 0x12,
+			0x7e, 0xe4, 0xd4, 0xfb, 0x64, 0xad, 0xbb, 0x8e, 0x33, 0x61,
+			0x09, 0x48, 0x85, 0x68, 0xdc, 0x77, 0xcb, 0xcf, 0x14, 0x5f,
+			0x19, 0x2a, 0x89, 0x95, 0x6f, 0x19, 0xd0, 0xd7, 0xcb, 0xf5,
+			0x08, 0x7c, 0xa0, 0xf9, 0xd9, 0xc3, 0x43, 0x8f, 0x8f, 0xa5,
+			0x0d, 0xa7, 0x17, 0x21, 0x77, 0xcd, 0x24, 0x18, 0x33, 0x13,
+			0x36, 0x19, 0xb3, 0x5c, 0x4d, 0x90, 0x8f, 0xd3, 0xd9, 0x66,
+			0x54, 0x66, 0x65, 0xfa, 0x59, 0x08, 0x2b, 0x73, 0x64, 0x02,
+			0xb2, 0x74, 0x55, 0x68, 0xb9, 0x00, 0x28, 0xbc, 0x06, 0xe8,
+			0x4d, 0x5d, 0x38, 0xcd, 0x4b, 0xe5, 0x2b, 0x03, 0x0d, 0x47,
+			0x94, 0xea, 0x5d, 0xea, 0x52, 0x13, 0x6e, 0xc8, 0x1b, 0x0f,
+			0x73, 0xf8, 0xad, 0x0f, 0xae, 0xc5,
+		},
+		SharedKey: []byte{
+			0xc8, 0xe1, 0xde, 0x8e, 0x83, 0xce, 0x47, 0x7c, 0x56, 0x32,
+			0x80, 0xc6, 0x2a, 0xed, 0xf7, 0x5e, 0x48, 0xbb, 0xdb, 0x69,
+			0x4b, 0xca, 0xef, 0xe0, 0x06, 0x03, 0x1f, 0x65, 0x3f, 0xfb,
+			0x1e, 0xac, 0xf3, 0x4d, 0x0b, 0xa2, 0x80, 0xd5, 0x26, 0x34,
+			0x8c, 0x95, 0xc9, 0xf7, 0x50, 0x3f, 0x50, 0x9e, 0x5d, 0x76,
+			0x1d, 0x04, 0xfd, 0x84, 0xde, 0x9f, 0xb0, 0x46, 0xdf, 0x41,
+			0x34, 0x9e, 0xec, 0x9e, 0x46, 0x76, 0x84, 0xf1, 0xa1, 0x35,
+			0x32, 0x2c, 0x68, 0xe7, 0xfa, 0xce, 0x12, 0xda, 0xe4, 0x20,
+			0xb7, 0x96, 0x30, 0xe8, 0x83, 0xbd, 0xa5, 0xb7, 0xa7, 0x68,
+			0x8c, 0xff, 0xfd, 0xbc, 0xbf, 0xa3, 0x82, 0xd1, 0x28, 0xea,
+			0x75, 0x95, 0xf7, 0xa1, 0x87, 0x22, 0x34, 0x4b, 0x2f, 0x9f,
+			0x9a, 0xb7, 0xab, 0x7d, 0xec, 0x6c, 0x3c, 0x14, 0x15, 0x0f,
+			0xb5, 0xcc, 0x25, 0xc9, 0xc9, 0xca, 0x52, 0x5e, 0x65, 0xe2,
+			0xf9, 0xc5, 0xf8, 0x4b, 0x9e, 0x66, 0xb1, 0xba, 0x9e, 0xd7,
+			0xd0, 0x01, 0x03, 0x8a, 0x36, 0xea, 0xe3, 0x31, 0xe6, 0x01,
+			0x71, 0xf7, 0xba, 0xaf, 0xe9, 0x99, 0xd4, 0x91, 0xf6, 0x7e,
+			0x62, 0xa4, 0x47, 0x87, 0xf6, 0x0c, 0x4c, 0x28, 0x55, 0x84,
+			0xfa, 0x82, 0xa7, 0xbf, 0x4b, 0xef, 0xd7, 0x80, 0xeb, 0x30,
+			0x65, 0xe9, 0x10, 0x74, 0xea, 0xe9, 0x43, 0x4d, 0x26, 0x2f,
+			0x32, 0x0a, 0x46, 0x29, 0x7b, 0x4a, 0x76, 0x16, 0xfc, 0xae,
+			0xc0, 0x41, 0xb4, 0xd7, 0x01, 0x68, 0xf8, 0x73, 0xe3, 0x25,
+			0x0d, 0x84, 0xce, 0xda, 0xe7, 0xfc, 0x4f, 0x0a, 0xfb, 0x87,
+			0x34, 0x2e, 0xdb, 0x64, 0xf1, 0x1b, 0x1f, 0x75, 0x04, 0xdc,
+			0xda, 0xa3, 0xe6, 0xb3, 0x68, 0x09, 0xb2, 0x49, 0x22, 0x63,
+			0x21, 0xc7, 0x03, 0xd1, 0x8e, 0xbd, 0x6f, 0xc4, 0xf4, 0x58,
+			0xca, 0xde, 0x55, 0xe7, 0x7e, 0x08,
+		},
+	},
+	{
+		PrivateKeyA: []byte{
+			0xec, 0x3e, 0x3d, 0xa7, 0x54, 0x6f, 0x3c, 0x6f, 0x5a, 0x87,
+			0xf8, 0xad, 0x2a, 0x63, 0xc5, 0x11, 0xee, 0x28, 0x0a, 0x26,
+			0x0e, 0xc1, 0x5c, 0x38, 0x2e, 0xa3, 0xee, 0xb4, 0x17, 0x97,
+			0x7b, 0x7d,
+		},
+		PrivateKeyB: []byte{
+			0x87, 0x45, 0xf3, 0xd3, 0x3f, 0xf6, 0xb4, 0x55, 0xe4, 0xff,
+			0x54, 0xc2, 0xef, 0xed, 0x67, 0x0c, 0xc0, 0x28, 0x71, 0x36,
+			0xf4, 0x58, 0x75, 0xca, 0xd8, 0xd4, 0x8d, 0xb8, 0x7b, 0x3d,
+			0x4f, 0x2f,
+		},
+		PublicKeyA: []byte{
+			0xd3, 0xf5, 0x99, 0x65, 0xb6, 0xaa, 0x6c, 0xec, 0xf8, 0xd3,
+			0x35, 0xf2, 0x26, 0x50, 0xd2, 0xa6, 0xdd, 0x2b, 0x0e, 0xd6,
+			0x4b, 0x7c, 0x01, 0xbe, 0xe3, 0xb3, 0x6c, 0x7f, 0xc6, 0x7e,
+			0xda, 0x23, 0x72, 0xdb, 0x22, 0x32, 0x57, 0x30, 0x19, 0x50,
+			0xbe, 0x09, 0x22, 0xd8, 0xf8, 0x20, 0x27, 0xf5, 0x08, 0x71,
+			0xc7, 0x21, 0xe5, 0x57, 0x6d, 0xb6, 0x07, 0x17, 0x98, 0xd1,
+			0x4f, 0x73, 0xc9, 0xc1, 0x3b, 0x05, 0x77, 0xec, 0xf8, 0x50,
+			0x2a, 0x06, 0xa7, 0xb9, 0xd0, 0xa4, 0x02, 0xdf, 0xfc, 0xb1,
+			0x6c, 0xf7, 0x5d, 0x3b, 0xfb, 0x55, 0x48, 0x8e, 0xd7, 0x9d,
+			0x30, 0xa9, 0x8e, 0x00, 0x51, 0x3f, 0x11, 0xb3, 0xfe, 0xbd,
+			0x61, 0x3d, 0xf3, 0x66, 0x0b, 0x5a, 0x54, 0x8d, 0x65, 0x30,
+			0x3b, 0x63, 0x20, 0x9a, 0xa5, 0x78, 0x98, 0xdd, 0xf3, 0x37,
+			0x78, 0x0e, 0x9b, 0xc9, 0x34, 0xe3, 0x11, 0xee, 0xe5, 0x4b,
+			0xa2, 0x19, 0xe7, 0x66, 0xa2, 0xa2, 0x10, 0xaf, 0x6e, 0xf7,
+			0x85, 0x5b, 0xca, 0x83, 0x29, 0x24, 0xf9, 0x76, 0x35, 0xb3,
+			0x80, 0x0a, 0x40, 0xf6, 0xf3, 0xd6, 0xb9, 0x50, 0x70, 0x76,
+			0x7d, 0x67, 0x15, 0xfa, 0xaa, 0x5d, 0x3e, 0xd2, 0x2a, 0x25,
+			0x5f, 0x4c, 0x77, 0x60, 0x9e, 0x34, 0x28, 0xc5, 0x28, 0x8b,
+			0x00, 0x71, 0x89, 0x7d, 0xe8, 0x4d, 0x7e, 0x06, 0xe9, 0x93,
+			0x31, 0xfb, 0xae, 0x59, 0xe7, 0x00, 0xe0, 0xd1, 0x6f, 0xcc,
+			0xce, 0xfc, 0x62, 0x82, 0xc4, 0xe0, 0xaa, 0x77, 0xd0, 0x24,
+			0x96, 0x70, 0x27, 0x80, 0x0a, 0x94, 0xb2, 0x31, 0xe6, 0x13,
+			0xf2, 0x95, 0xea, 0xc5, 0x6f, 0xf8, 0x69, 0x0c, 0xdf, 0x9a,
+			0x11, 0x64, 0xab, 0x69, 0x69, 0xfe, 0x35, 0x91, 0x6c, 0xb6,
+			0xea, 0xc9, 0x37, 0x6e, 0xaa, 0xa8, 0x55, 0x87, 0x14, 0x59,
+			0xf2, 0x46, 0x15, 0xc8, 0x14, 0x9d,
+		},
+		PublicKeyB: []byte{
+			0x47, 0xbc, 0x53, 0xc0, 0xe8, 0xbb, 0xd4, 0x15, 0x3a, 0xcf,
+			0xe4, 0xfd, 0xbf, 0x33, 0xd7, 0xae, 0xbc, 0xab, 0xc5, 0x22,
+			0xa2, 0x1b, 0x50, 0xc3, 0x16, 0xdf, 0x13, 0x29, 0x5b, 0x5c,
+			0x07, 0x45, 0x8a, 0x25, 0x09, 0x95, 0xfa, 0xae, 0x2c, 0xe2,
+			0x44, 0x4f, 0xe9, 0x40, 0xfd, 0xce, 0x36, 0x34, 0x97, 0x12,
+			0xa6, 0xbe, 0x82, 0x2a, 0x2a, 0xdb, 0x6a, 0x5e, 0x4d, 0x2b,
+			0x0c, 0xfe, 0x42, 0x33, 0x68, 0x4b, 0xe6, 0xfa, 0x87, 0xd8,
+			0x99, 0x7c, 0x70, 0x39, 0x17, 0x8d, 0x25, 0x99, 0x95, 0xb8,
+			0x1a, 0xc9, 0x97, 0xb2, 0xf4, 0xc5, 0x04, 0x78, 0x80, 0x07,
+			0x69, 0xfd, 0x2d, 0x76, 0x5b, 0x65, 0x5e, 0x44, 0xde, 0x06,
+			0x95, 0xd8, 0x4a, 0xc6, 0xc4, 0x3f, 0xbc, 0x8f, 0x6d, 0x48,
+			0x8d, 0x78, 0x68, 0x81, 0xe4, 0x98, 0xa3, 0xb4, 0xc9, 0xfd,
+			0x72, 0x9b, 0xda, 0x72, 0x1a, 0x39, 0x1b, 0x5c, 0x1c, 0xf0,
+			0x5d, 0xd3, 0x36, 0xc7, 0xfa, 0xa3, 0x76, 0xb0, 0x1e, 0x7d,
+			0x47, 0x6d, 0xb2, 0xb3, 0xe7, 0xd7, 0x69, 0x84, 0xf2, 0xb5,
+			0xa0, 0xd4, 0xd3, 0x45, 0x31, 0x93, 0xcd, 0xa2, 0xe8, 0x3a,
+			0x62, 0xaa, 0x8c, 0xac, 0x30, 0x32, 0xa9, 0x57, 0x8c, 0x1c,
+			0x3e, 0xf0, 0x3b, 0x34, 0x7d, 0xf9, 0x36, 0x2b, 0xc8, 0xb6,
+			0xe8, 0x57, 0x9a, 0x79, 0x5a, 0x2d, 0x6f, 0xbb, 0x9b, 0x64,
+			0xa0, 0x5e, 0xfc, 0xb3, 0x8f, 0xf4, 0xc1, 0xdc, 0xca, 0x77,
+			0xb3, 0x0a, 0xc7, 0xba, 0xaf, 0x95, 0x3c, 0xf7, 0x3c, 0xd4,
+			0x2f, 0x98, 0xa7, 0x65, 0x5c, 0xc7, 0xa7, 0x93, 0x9c, 0xc4,
+			0xcc, 0x16, 0xd7, 0x47, 0x0e, 0xf5, 0xbb, 0xbd, 0xef, 0x08,
+			0x1b, 0xf8, 0xfc, 0x67, 0x10, 0x23, 0xb6, 0x91, 0x9e, 0xee,
+			0xb9, 0xca, 0x4e, 0xce, 0xbf, 0xc7, 0x94, 0x15, 0x05, 0x43,
+			0x03, 0x6f, 0x03, 0x9f, 0x29, 0x02,
+		},
+		SharedKey: []byte{
+			0xc9, 0xea, 0xcf, 0x8e, 0xa8, 0x39, 0x92, 0x28, 0x34, 0xa8,
+			0xc6, 0x40, 0x87, 0x0a, 0x26, 0x58, 0x2e, 0x60, 0x4b, 0x47,
+			0x51, 0x44, 0x9f, 0x44, 0x46, 0xa1, 0xb8, 0x6e, 0xc2, 0xcf,
+			0x30, 0xa9, 0x70, 0x31, 0x9d, 0xbe, 0xd5, 0x8f, 0x9d, 0x9d,
+			0xb2, 0x11, 0x03, 0x1d, 0x79, 0xef, 0x3b, 0x97, 0x8c, 0x77,
+			0x37, 0x3d, 0xba, 0xa4, 0x12, 0x8d, 0xc6, 0x88, 0x45, 0xe8,
+			0x85, 0x39, 0x5c, 0x48, 0xe1, 0x0e, 0xdd, 0x81, 0x61, 0x66,
+			0x51, 0x08, 0xec, 0xfa, 0xfd, 0x63, 0x2f, 0xe4, 0x8c, 0x4f,
+			0x53, 0x3c, 0xd4, 0x5b, 0x06, 0xec, 0x4d, 0x5a, 0x87, 0xbb,
+			0xd5, 0x8e, 0x90, 0xca, 0x35, 0x34, 0xb7, 0x41, 0xf7, 0xb5,
+			0x03, 0x7c, 0x2d, 0xc5, 0x04, 0x58, 0xcb, 0x2f, 0x97, 0x51,
+			0x54, 0x79, 0xe1, 0xb8, 0x28, 0x24, 0x4c, 0x61, 0xa5, 0x4a,
+			0xd5, 0xea, 0xa1, 0xdf, 0x7a, 0xda, 0xe5, 0x4e, 0x58, 0xfb,
+			0x09, 0x98, 0xff, 0x9c, 0x1e, 0xdf, 0x78, 0x36, 0x7a, 0x43,
+			0x78, 0xa5, 0xe9, 0xcb, 0x34, 0xfe, 0xfe, 0x0b, 0x80, 0x0a,
+			0xb8, 0x2d, 0xd6, 0xb9, 0xe0, 0x44, 0x5c, 0x7d, 0x74, 0xe8,
+			0x7a, 0x28, 0x48, 0xcc, 0xfd, 0x6e, 0x24, 0xc5, 0x17, 0x50,
+			0x64, 0xca, 0xad, 0x5a, 0xc5, 0x7d, 0x5c, 0x3a, 0x02, 0x7c,
+			0xd1, 0xb7, 0xb4, 0xf6, 0x3f, 0x76, 0x0c, 0x05, 0xa8, 0x5f,
+			0xae, 0x75, 0xa3, 0xa6, 0xea, 0x84, 0x95, 0x6f, 0x53, 0xac,
+			0x69, 0xbb, 0xc3, 0x72, 0xb4, 0x6a, 0x43, 0xc6, 0x6a, 0x56,
+			0x2c, 0xf1, 0x2f, 0x30, 0x76, 0x01, 0xda, 0x09, 0x6b, 0x4b,
+			0x35, 0x3e, 0x11, 0xe2, 0x69, 0x78, 0xbf, 0xc0, 0x99, 0x9f,
+			0x92, 0x9e, 0x69, 0x4c, 0x43, 0xd9, 0xac, 0xe6, 0x50, 0xb9,
+			0x1b, 0xa7, 0x0c, 0x1d, 0x25, 0xcf, 0xb9, 0x5c, 0x28, 0x77,
+			0x06, 0x57, 0x52, 0x8c, 0x76, 0xf6,
+		},
+	},
+	{
+		PrivateKeyA: []byte{
+			0x5c, 0x8b, 0x39, 0x0a, 0x17, 0x08, 0x9b, 0x64, 0x86, 0x87,
+			0xf1, 0x8b, 0x23, 0xfc, 0xdf, 0xbb, 0xf8, 0x1b, 0x45, 0x95,
+			0x21, 0x03, 0x77, 0xf6, 0xb6, 0x50, 0x4d, 0x5f, 0x5d, 0xd0,
+			0x41, 0x1a,
+		},
+		PrivateKeyB: []byte{
+			0xfa, 0x94, 0x36, 0x9c, 0x0b, 0xb3, 0x35, 0xf0, 0xc8, 0x7e,
+			0x74, 0x09, 0x02, 0x5c, 0x10, 0x32, 0xda, 0x51, 0xcb, 0x4a,
+			0xa7, 0xfe, 0x47, 0x98, 0x8a, 0x45, 0x8e, 0x4e, 0x67, 0xba,
+			0xed, 0x0c,
+		},
+		PublicKeyA: []byte{
+			0x48, 0xb9, 0x9d, 0x9c, 0x81, 0xfc, 0x87, 0x05, 0xf9, 0x81,
+			0x96, 0xb3, 0xc2, 0xf7, 0xa3, 0x6e, 0x95, 0xe3, 0x30, 0x4a,
+			0xe3, 0x5a, 0x22, 0x5d, 0x16, 0x20, 0xe9, 0x4e, 0xed, 0xe4,
+			0x4b, 0xee, 0x3f, 0xad, 0xc7, 0x33, 0x00, 0x26, 0x66, 0x9e,
+			0xc1, 0xe4, 0x08, 0xfc, 0x1f, 0x2c, 0x25, 0x82, 0x45, 0x5b,
+			0xbf, 0x2b, 0xb0, 0xee, 0xa9, 0xa1, 0xc3, 0x82, 0x88, 0x50,
+			0x2e, 0x89, 0x47, 0x04, 0x77, 0xfe, 0xae, 0xe2, 0xbb, 0x0d,
+			0x7c, 0xe8, 0xb3, 0xcc, 0x61, 0x9c, 0x57, 0x42, 0x47, 0xbd,
+			0xca, 0xbc, 0xbd, 0xb0, 0x7d, 0x48, 0x78, 0x40, 0xc9, 0x75,
+			0x8f, 0x90, 0x43, 0x7f, 0x15, 0x7c, 0x8f, 0xd1, 0xc5, 0x71,
+			0x68, 0xcb, 0x70, 0x12, 0x70, 0x18, 0x58, 0x63, 0xc9, 0x3c,
+			0xbd, 0x7b, 0x89, 0x1f, 0xb9, 0xbc, 0x2d, 0xea, 0x28, 0x99,
+			0x26, 0x86, 0x46, 0x66, 0x78, 0xf7, 0x00, 0x30, 0x5c, 0xab,
+			0x21, 0xc3, 0x2e, 0x03, 0x19, 0x83, 0x40, 0xcb, 0x35, 0x3f,
+			0xf4, 0x77, 0x13, 0xcd, 0xc1, 0x0a, 0xfd, 0x47, 0xd2, 0x09,
+			0x98, 0x74, 0x71, 0x96, 0xe8, 0x80, 0x25, 0x4c, 0xdf, 0x7a,
+			0x1a, 0xbe, 0x96, 0x5f, 0x9e, 0x85, 0x58, 0xa7, 0x1b, 0xa1,
+			0xe8, 0x25, 0x33, 0x16, 0xe4, 0xc7, 0x34, 0xbc, 0x24, 0x49,
+			0x73, 0xf0, 0xe9, 0x5b, 0x15, 0x76, 0xc5, 0x0d, 0xd6, 0xec,
+			0x92, 0x59, 0xf8, 0x55, 0x4c, 0x13, 0xac, 0xba, 0x6d, 0x7a,
+			0x4c, 0xa5, 0xcc, 0xb2, 0x7c, 0x2f, 0x2d, 0xca, 0xb8, 0x80,
+			0x71, 0x66, 0x65, 0x41, 0x00, 0x62, 0x3c, 0xdb, 0x71, 0x83,
+			0x79, 0x48, 0x99, 0xdf, 0x80, 0x43, 0x1e, 0x76, 0xde, 0x87,
+			0x1a, 0x8d, 0x1a, 0xb2, 0x27, 0xfe, 0x28, 0x74, 0x71, 0x00,
+			0xc0, 0xbe, 0x00, 0xd3, 0x13, 0x21, 0x6f, 0x31, 0xe0, 0xe9,
+			0x12, 0x3d, 0x49, 0x43, 0x70, 0x18,
+		},
+		PublicKeyB: []byte{
+			0x1a, 0x3b, 0xd8, 0x2f, 0x0a, 0x2c, 0x5c, 0x02, 0x36, 0xdb,
+			0x91, 0xab, 0x50, 0x6d, 0xd5, 0xd4, 0xe6, 0x84, 0x0f, 0x87,
+			0x3b, 0x47, 0xa0, 0xe0, 0x9f, 0x92, 0xe5, 0xcb, 0x92, 0x57,
+			0x3c, 0x2a, 0xb6, 0x78, 0xd4, 0x28, 0xd9, 0x16, 0x28, 0xdb,
+			0x31, 0x1f, 0x27, 0xe4, 0xfe, 0xde, 0x23, 0xc4, 0xee, 0xce,
+			0x8e, 0xd0, 0x18, 0xf9, 0x0e, 0xc5, 0x21, 0xae, 0x32, 0x13,
+			0x97, 0xb6, 0x77, 0x20, 0x52, 0xa2, 0x67, 0x5f, 0x11, 0x72,
+			0x05, 0x64, 0x95, 0x92, 0xc9, 0x78, 0x35, 0x8d, 0x68, 0xbc,
+			0x58, 0xbd, 0x9b, 0xe7, 0x25, 0x1e, 0x9a, 0x82, 0x2f, 0xd5,
+			0x6a, 0xef, 0x03, 0xcf, 0x99, 0xba, 0xd3, 0xbe, 0x87, 0x9a,
+			0xc1, 0xea, 0x37, 0x1d, 0x2f, 0x3a, 0x38, 0x06, 0xb3, 0x51,
+			0x01, 0x81, 0x8f, 0xdc, 0x10, 0x39, 0xd0, 0x0e, 0xd5, 0x84,
+			0x24, 0x94, 0xaf, 0x10, 0x10, 0x59, 0x9e, 0xd7, 0x2b, 0x1a,
+			0x04, 0xd2, 0x40, 0x92, 0x88, 0xdb, 0xe2, 0xef, 0x54, 0x91,
+			0x1f, 0x36, 0x3e, 0x0d, 0x39, 0x5c, 0xda, 0x31, 0x45, 0x2b,
+			0x39, 0xf8, 0x20, 0xde, 0x9f, 0x13, 0x2a, 0xe6, 0x2f, 0x37,
+			0xd1, 0x72, 0xd3, 0x05, 0xcb, 0xf8, 0x12, 0xfd, 0xd7, 0x1d,
+			0xe9, 0x1f, 0xce, 0xdd, 0x1e, 0x7d, 0x75, 0x7e, 0x2f, 0x70,
+			0xad, 0xe3, 0x77, 0x87, 0xa5, 0xde, 0x3e, 0x51, 0x44, 0x07,
+			0xbf, 0x07, 0x42, 0x02, 0x10, 0x01, 0x94, 0x85, 0xc7, 0xf6,
+			0xfe, 0xcb, 0xe0, 0x48, 0xb3, 0x86, 0x42, 0x18, 0x02, 0xe5,
+			0x0b, 0x53, 0xdd, 0xba, 0x90, 0xd3, 0xce, 0xb7, 0xf2, 0xc8,
+			0xc8, 0x83, 0x43, 0xde, 0xef, 0xcd, 0xce, 0xab, 0xed, 0x3f,
+			0xb5, 0xd4, 0x54, 0xc7, 0x3a, 0xa1, 0x03, 0x83, 0xdb, 0x5d,
+			0xc4, 0xf9, 0x8c, 0x58, 0x69, 0x87, 0x35, 0xe4, 0x41, 0x14,
+			0xa6, 0x5e, 0x0b, 0x33, 0x13, 0x11,
+		},
+		SharedKey: []byte{
+			0x78, 0xc9, 0x8e, 0x7d, 0xe2, 0x0f, 0xf7, 0xba, 0xe6, 0x0b,
+			0x44, 0x12, 0xd2, 0x30, 0x58, 0x1b, 0x9c, 0x7c, 0x57, 0xda,
+			0x3f, 0x71, 0x3f, 0x9a, 0xcf, 0x41, 0xc0, 0x7c, 0x8c, 0x8f,
+			0xba, 0xd4, 0x27, 0x77, 0x36, 0x34, 0x7c, 0x36, 0x16, 0x25,
+			0xa4, 0xd0, 0x93, 0x94, 0x72, 0x80, 0xda, 0x7d, 0x3f, 0x77,
+			0xfe, 0x13, 0x6e, 0xe4, 0xe7, 0xad, 0xa0, 0x22, 0x2a, 0xe6,
+			0x23, 0x99, 0xda, 0xed, 0x31, 0x94, 0x89, 0xa0, 0x08, 0x81,
+			0xdd, 0x20, 0x5a, 0x67, 0xa4, 0x98, 0xac, 0x01, 0xe0, 0x06,
+			0x88, 0x70, 0x54, 0x77, 0xf4, 0xaf, 0xf6, 0xaf, 0x19, 0x79,
+			0x82, 0x82, 0x2e, 0xf5, 0xa6, 0x47, 0xb6, 0xfa, 0x68, 0x19,
+			0x9e, 0x8d, 0xac, 0xa3, 0x92, 0x0c, 0xac, 0xef, 0x92, 0x1a,
+			0x0c, 0xc4, 0xce, 0x5f, 0x02, 0x55, 0x71, 0x2b, 0x48, 0xbf,
+			0x38, 0x7a, 0xbe, 0x30, 0xad, 0x1f, 0xe1, 0xb4, 0xf5, 0x6c,
+			0xcb, 0x42, 0xab, 0x87, 0x7a, 0xc5, 0xaa, 0x4d, 0xc5, 0xe7,
+			0x87, 0x24, 0xf4, 0x24, 0xd8, 0x50, 0xe9, 0x8e, 0xd8, 0x14,
+			0xc3, 0x0c, 0x52, 0x0a, 0xaf, 0x2d, 0xe4, 0x80, 0x16, 0xcd,
+			0x13, 0x6e, 0x16, 0x81, 0xea, 0xf5, 0x90, 0x5c, 0x82, 0xfd,
+			0xf1, 0xe4, 0x76, 0x4d, 0x58, 0x3d, 0xe8, 0x17, 0xb0, 0x0e,
+			0xfa, 0x70, 0xf2, 0x05, 0xec, 0xea, 0x20, 0x3d, 0x07, 0x3a,
+			0x1e, 0xd0, 0x7b, 0x07, 0x35, 0x38, 0xed, 0x16, 0x63, 0x89,
+			0x50, 0x58, 0x18, 0xa1, 0x58, 0xf8, 0x54, 0xa4, 0x8e, 0x4a,
+			0xf1, 0x82, 0x2b, 0x2a, 0x53, 0xcf, 0xbb, 0x10, 0x89, 0x28,
+			0x34, 0x72, 0x1c, 0xab, 0x48, 0xc1, 0xe1, 0x0d, 0xa6, 0x97,
+			0x01, 0xe1, 0x49, 0xe3, 0x9a, 0xae, 0x38, 0x04, 0x2f, 0xd1,
+			0x04, 0x23, 0x25, 0x07, 0x71, 0x35, 0xc2, 0x65, 0x6e, 0x89,
+			0x0c, 0x9b, 0x70, 0xc0, 0xad, 0xe7,
+		},
+	},
+	{
+		PrivateKeyA: []byte{
+			0x66, 0xcf, 0x99, 0x73, 0x61, 0x41, 0x60, 0xb1, 0x67, 0x02,
+			0x45, 0xa7, 0xd1, 0x2e, 0x5a, 0x0d, 0x64, 0xfb, 0x41, 0x72,
+			0xfd, 0x68, 0xe0, 0x6d, 0xe0, 0x19, 0x96, 0x4f, 0xcd, 0x5e,
+			0x62, 0xc5,
+		},
+		PrivateKeyB: []byte{
+			0xb6, 0x75, 0xd8, 0x30, 0xa4, 0xe2, 0xb3, 0x06, 0xa9, 0xde,
+			0x9f, 0x8f, 0xda, 0xcc, 0x9b, 0x51, 0x53, 0x8d, 0xbd, 0x0f,
+			0x2b, 0xd6, 0xa1, 0x4d, 0x25, 0xb8, 0x2d, 0x83, 0xd8, 0x1f,
+			0x85, 0x5f,
+		},
+		PublicKeyA: []byte{
+			0x32, 0x36, 0xcf, 0x3a, 0x0b, 0x5c, 0xa3, 0x7b, 0x21, 0x1f,
+			0xee, 0xd6, 0x09, 0xba, 0x95, 0x5c, 0x01, 0x3b, 0x51, 0x10,
+			0x2f, 0xd5, 0xfe, 0x1f, 0x82, 0x41, 0xdd, 0x47, 0xc8, 0x18,
+			0xdc, 0x96, 0x95, 0x0e, 0x94, 0x0e, 0x47, 0x53, 0xe9, 0x97,
+			0x26, 0x23, 0x79, 0x56, 0xa8, 0x2c, 0xd7, 0x7a, 0xde, 0x1a,
+			0xdc, 0xa2, 0x33, 0x83, 0xa0, 0x36, 0x8a, 0x34, 0x3a, 0xaa,
+			0x2d, 0xc4, 0x38, 0x04, 0x9e, 0xb9, 0xe1, 0xa2, 0x66, 0x99,
+			0x2f, 0xad, 0x53, 0x1f, 0xf6, 0xab, 0x6a, 0x88, 0xbb, 0xb9,
+			0x2f, 0x27, 0xdc, 0x8e, 0x2d, 0x48, 0xc8, 0x8f, 0x7f, 0xbd,
+			0x07, 0x64, 0x7a, 0xd5, 0x9f, 0x31, 0x67, 0xfb, 0xee, 0x5c,
+			0x89, 0x12, 0x04, 0x90, 0x62, 0x7d, 0x7d, 0xd8, 0xac, 0x63,
+			0x9c, 0x54, 0x69, 0x20, 0xbe, 0xad, 0x1d, 0x6c, 0x95, 0xf0,
+			0xd0, 0x81, 0x4d, 0xe8, 0x77, 0x40, 0x4a, 0xa0, 0xac, 0xd7,
+			0x19, 0x9c, 0x04, 0x31, 0x8a, 0xe8, 0x95, 0x8c, 0x6c, 0x74,
+			0x09, 0xa0, 0x3d, 0xa4, 0xcf, 0xdc, 0x8c, 0x2e, 0x03, 0xb1,
+			0x68, 0x33, 0x30, 0x37, 0x8b, 0x93, 0xd9, 0xe1, 0xae, 0x83,
+			0x2e, 0xea, 0xa1, 0xba, 0xe3, 0x70, 0x72, 0xd9, 0xb8, 0xc5,
+			0xd6, 0x8f, 0x70, 0x8f, 0xb8, 0x17, 0xe0, 0x52, 0x62, 0x34,
+			0x06, 0x27, 0xcb, 0x95, 0xa3, 0x67, 0x60, 0xf5, 0x70, 0xf2,
+			0xbe, 0xf9, 0x6a, 0x2f, 0x81, 0x1a, 0x08, 0x9f, 0x92, 0x21,
+			0x6d, 0x5c, 0xcf, 0xb1, 0x4a, 0xd7, 0xe2, 0x00, 0x75, 0x95,
+			0x21, 0x7b, 0x09, 0x42, 0x0a, 0x3f, 0x91, 0x09, 0xbe, 0xc5,
+			0xf7, 0x09, 0x0e, 0xf2, 0xcd, 0x70, 0xd9, 0x1a, 0x82, 0xa6,
+			0x15, 0xf3, 0x6f, 0x9d, 0x17, 0x50, 0x6a, 0xa9, 0x17, 0x7e,
+			0x89, 0xf9, 0x22, 0xd7, 0x6e, 0xc9, 0x03, 0x98, 0x64, 0xd4,
+			0xef, 0x0a, 0x14, 0x9c, 0xb3, 0x42,
+		},
+		PublicKeyB: []byte{
+			0x8b, 0x42, 0x2b, 0x43, 0x0d, 0x3d, 0x24, 0x88, 0x33, 0xb5,
+			0x0b, 0xc4, 0x52, 0x68, 0x64, 0x7f, 0x98, 0x10, 0x96, 0x7b,
+			0x86, 0xab, 0x58, 0xff, 0x91, 0xce, 0xf7, 0xfb, 0x0a, 0x50,
+			0x6f, 0x08, 0xed, 0x88, 0x7f, 0xbf, 0xa3, 0x9e, 0x0c, 0x34,
+			0x02, 0x07, 0xaa, 0x3a, 0xfe, 0x22, 0x7d, 0x26, 0xb4, 0x93,
+			0xeb, 0x21, 0x8c, 0x2a, 0x73, 0x19, 0xbd, 0x4f, 0x67, 0x58,
+			0xda, 0x2b, 0x09, 0x4a, 0x74, 0x64, 0x2f, 0x31, 0x1b, 0xca,
+			0x94, 0xc9, 0x09, 0x9b, 0xc5, 0xf0, 0xf8, 0xe4, 0x3d, 0x80,
+			0x78, 0xf1, 0x41, 0x9a, 0xc5, 0xde, 0x55, 0xe8, 0xdb, 0x3c,
+			0xe3, 0x20, 0x71, 0x05, 0x44, 0x7b, 0xd9, 0x7b, 0x18, 0xfc,
+			0x6b, 0x49, 0x17, 0xf3, 0xfd, 0x76, 0x63, 0x3a, 0xfc, 0x2f,
+			0xe1, 0x37, 0x4b, 0x24, 0xcc, 0xc7, 0x9a, 0x84, 0x35, 0x0f,
+			0xa3, 0x1f, 0xda, 0xdf, 0x1e, 0x02, 0x63, 0x45, 0x62, 0x1c,
+			0xde, 0xa6, 0x3a, 0x4b, 0x7b, 0x13, 0x5d, 0xc9, 0x75, 0xeb,
+			0xa1, 0xaf, 0x24, 0xf5, 0xdb, 0x57, 0x83, 0x69, 0xa9, 0x78,
+			0x48, 0xec, 0x70, 0xe1, 0x5c, 0x04, 0x42, 0x31, 0x33, 0xdd,
+			0x02, 0x99, 0x68, 0x7e, 0xcf, 0x7c, 0xdc, 0xd9, 0xd4, 0xaf,
+			0x5a, 0xc5, 0x78, 0xc1, 0xc1, 0xe0, 0xcd, 0x29, 0xf7, 0x91,
+			0xa9, 0x7f, 0xa6, 0x59, 0x2f, 0xbc, 0x59, 0xec, 0xb3, 0x0d,
+			0xa1, 0x76, 0x30, 0x0b, 0xad, 0x63, 0x72, 0x4c, 0x47, 0xf4,
+			0x6e, 0x3c, 0xf3, 0xf9, 0x1a, 0x3b, 0x78, 0x9f, 0xe4, 0xc0,
+			0x86, 0xd4, 0x53, 0xc1, 0xe4, 0xe6, 0x82, 0x1e, 0xcc, 0x19,
+			0x05, 0x05, 0xfe, 0x73, 0x73, 0x07, 0xa3, 0xe6, 0xb2, 0xac,
+			0x81, 0x35, 0xb3, 0x83, 0x49, 0x6a, 0x2d, 0x62, 0x58, 0x33,
+			0x29, 0x92, 0x85, 0x1d, 0x5d, 0x10, 0x6c, 0x9f, 0x0d, 0x50,
+			0x9d, 0x8f, 0x5e, 0x7b, 0xd0, 0xb6,
+		},
+		SharedKey: []byte{
+			0x5d, 0xa8, 0x3c, 0x4b, 0xf1, 0xef, 0x06, 0x24, 0x13, 0xde,
+			0xe4, 0xd5, 0xae, 0x77, 0x62, 0xed, 0x55, 0xee, 0x9f, 0x5c,
+			0x86, 0xfb, 0x46, 0x4f, 0x46, 0x6b, 0x54, 0x5f, 0x3a, 0x7a,
+			0x15, 0x7c, 0x7e, 0x93, 0x38, 0xf1, 0x29, 0x9d, 0xd1, 0xf6,
+			0x70, 0x49, 0xf9, 0x3b, 0xf9, 0x35, 0x5e, 0x52, 0x74, 0xde,
+			0x4e, 0x29, 0x11, 0x88, 0xdc, 0x42, 0xd9, 0x7c, 0xe7, 0x3a,
+			0x2a, 0xa5, 0x78, 0x5e, 0x0d, 0xdb, 0x71, 0xa6, 0xb0, 0x2d,
+			0x9c, 0xfe, 0xf9, 0xde, 0x19, 0x4c, 0xa2, 0x62, 0xa6, 0x58,
+			0xa7, 0xcf, 0x8c, 0x6f, 0xab, 0x8d, 0x7a, 0x89, 0xad, 0xed,
+			0x5c, 0xa4, 0x64, 0xa0, 0x16, 0xc8, 0xaf, 0x25, 0x63, 0xdb,
+			0x8c, 0xb6, 0x5e, 0xb9, 0xdb, 0xe9, 0xa3, 0xf0, 0xcf, 0xfc,
+			0xe8, 0x1f, 0x13, 0x44, 0xc5, 0x41, 0x24, 0xd8, 0xd6, 0x6d,
+			0x42, 0xff, 0xc9, 0x25, 0x58, 0x7b, 0x19, 0xcb, 0xb2, 0x5a,
+			0x55, 0xc2, 0x82, 0x3c, 0x28, 0xed, 0xb0, 0x4e, 0x89, 0x00,
+			0x59, 0xff, 0x10, 0x5f, 0xd6, 0x15, 0xb9, 0x96, 0x94, 0xa8,
+			0x50, 0x6f, 0x5e, 0xa8, 0xa6, 0x4c, 0x60, 0x47, 0x44, 0x3e,
+			0xb7, 0x6c, 0x31, 0x1f, 0xd7, 0x98, 0x11, 0x6e, 0xdd, 0xe7,
+			0x0c, 0x41, 0x32, 0x61, 0x3e, 0xcf, 0xbe, 0x1e, 0x8a, 0x76,
+			0x03, 0x29, 0xf8, 0xa6, 0xd9, 0xaa, 0xc1, 0x51, 0x44, 0x50,
+			0x03, 0x74, 0x8e, 0xfd, 0x40, 0xd0, 0x09, 0xaf, 0xf0, 0x37,
+			0x09, 0x6e, 0xf5, 0x5d, 0xca, 0x0a, 0x67, 0xa6, 0x95, 0xda,
+			0x02, 0xe0, 0xd5, 0x83, 0x51, 0x5c, 0xd4, 0x94, 0xa5, 0xe3,
+			0xd1, 0xea, 0xcd, 0x67, 0xad, 0x2b, 0x76, 0x42, 0x9d, 0xeb,
+			0x8d, 0xaf, 0xdc, 0x46, 0x77, 0x3d, 0x7a, 0x41, 0x56, 0x35,
+			0x61, 0x07, 0x1c, 0x8b, 0x6a, 0x43, 0x51, 0x0a, 0x47, 0xcf,
+			0x24, 0xa2, 0x14, 0x5f, 0x72, 0x7d,
+		},
+	},
+	{
+		PrivateKeyA: []byte{
+			0x92, 0xb9, 0x74, 0xb7, 0x60, 0xf1, 0x81, 0xcc, 0xfe, 0xf5,
+			0x0e, 0x20, 0xb8, 0x8c, 0x77, 0x96, 0xd9, 0x7a, 0x79, 0xa0,
+			0x0d, 0x9a, 0xbe, 0x3e, 0x58, 0xd3, 0xd7, 0xbb, 0x7a, 0xc6,
+			0x18, 0x80,
+		},
+		PrivateKeyB: []byte{
+			0xd0, 0xf4, 0xbd, 0xad, 0x26, 0x69, 0xad, 0xfb, 0xa1, 0x51,
+			0x8b, 0x67, 0x89, 0xe2, 0xd3, 0xcd, 0x0e, 0x69, 0xc9, 0xc6,
+			0x40, 0xde, 0x3b, 0xc2, 0xa0, 0xed, 0xcb, 0x88, 0x51, 0x42,
+			0x87, 0xe4,
+		},
+		PublicKeyA: []byte{
+			0x8e, 0x60, 0x19, 0x07, 0xbe, 0xac, 0xe0, 0x57, 0x2d, 0x4f,
+			0xeb, 0x5c, 0x79, 0xe2, 0xce, 0x2a, 0x69, 0x3c, 0x76, 0xe5,
+			0x74, 0xd9, 0x5c, 0xec, 0xba, 0xe7, 0x2d, 0x8a, 0x23, 0x8b,
+			0xa8, 0x25, 0x08, 0xfe, 0x5d, 0x4a, 0x2d, 0x30, 0xc0, 0x98,
+			0xf4, 0x69, 0x7c, 0x46, 0x0a, 0xd8, 0x34, 0xd4, 0x9b, 0x63,
+			0xb4, 0x1c, 0xad, 0xb2, 0x0b, 0x90, 0x0b, 0x54, 0xe6, 0x14,
+			0xad, 0x88, 0x17, 0x1d, 0xd8, 0xb3, 0x76, 0xd1, 0xdc, 0xa5,
+			0x6d, 0x40, 0x73, 0x3f, 0xcf, 0x42, 0xce, 0x8c, 0xa5, 0xe1,
+			0xd0, 0x2e, 0xcf, 0x9f, 0x3e, 0x57, 0xab, 0x8e, 0xd1, 0x87,
+			0x92, 0x05, 0x28, 0xab, 0xe0, 0xf4, 0x02, 0xbe, 0xf4, 0xb8,
+			0x35, 0x6e, 0x2f, 0xcd, 0xd8, 0x2e, 0x97, 0x9c, 0x6c, 0xcb,
+			0xa8, 0x78, 0x9c, 0xd7, 0x12, 0xf4, 0xfe, 0x91, 0xb5, 0x57,
+			0xb3, 0xe3, 0x06, 0xe6, 0x90, 0xf6, 0x93, 0x8d, 0x3d, 0xcb,
+			0x88, 0x4d, 0xdb, 0x9d, 0xaa, 0xb7, 0x63, 0x73, 0xc5, 0x5d,
+			0x62, 0x63, 0x31, 0xbc, 0x00, 0x5f, 0xff, 0xeb, 0xaf, 0x80,
+			0x6c, 0xb6, 0x51, 0xa9, 0xbb, 0x79, 0xa6, 0x7d, 0xe4, 0xdc,
+			0x08, 0xd6, 0x19, 0x4d, 0xe4, 0x19, 0x9f, 0x45, 0xb1, 0x32,
+			0x57, 0x6f, 0x8b, 0x20, 0xfe, 0xa8, 0x2e, 0x21, 0x2b, 0x47,
+			0x4e, 0x61, 0x9c, 0xe6, 0x13, 0x17, 0xa2, 0x13, 0xa2, 0xa7,
+			0x80, 0x6a, 0x85, 0x5b, 0xd5, 0xd9, 0x99, 0xe6, 0x46, 0x6a,
+			0x2b, 0x87, 0xa3, 0xd2, 0xba, 0x5d, 0x57, 0xf1, 0xbc, 0x0b,
+			0xae, 0x86, 0xae, 0xfa, 0xcb, 0x77, 0x38, 0x30, 0x16, 0x57,
+			0xcd, 0x01, 0x92, 0x21, 0x68, 0x24, 0x7b, 0x80, 0xa0, 0x03,
+			0x32, 0xf3, 0xb1, 0xb2, 0xfc, 0x29, 0xd2, 0xbb, 0xa8, 0x10,
+			0xb3, 0xc5, 0x64, 0x9e, 0x4d, 0x13, 0x0b, 0x85, 0xd7, 0x3f,
+			0xea, 0x61, 0x09, 0x15, 0x99, 0x10,
+		},
+		PublicKeyB: []byte{
+			0x39, 0x84, 0x2d, 0x1f, 0xe2, 0x09, 0xa6, 0xb2, 0x3e, 0x74,
+			0x35, 0x93, 0xaa, 0x69, 0x0a, 0x72, 0x31, 0xed, 0x1b, 0xb6,
+			0xe2, 0x9c, 0xbd, 0xdc, 0x5f, 0x54, 0x3b, 0x9d, 0x47, 0x0d,
+			0xcc, 0x24, 0x34, 0xf3, 0x24, 0x3a, 0x94, 0xe4, 0x5e, 0x9c,
+			0x67, 0x51, 0x8f, 0xf6, 0x82, 0x05, 0xe9, 0x0b, 0x4e, 0xf5,
+			0x40, 0x26, 0x69, 0xe6, 0x0a, 0xf1, 0x45, 0x00, 0x7e, 0x38,
+			0xdb, 0xdb, 0x6c, 0x52, 0x63, 0x22, 0xbe, 0xb8, 0xed, 0xe6,
+			0xa1, 0x7d, 0x30, 0xc2, 0xa2, 0xf2, 0x0d, 0x46, 0xe4, 0xc6,
+			0x3c, 0x85, 0x39, 0xfa, 0xb0, 0x51, 0x68, 0x0c, 0x71, 0x17,
+			0x81, 0x4a, 0x87, 0x86, 0xe9, 0xe6, 0x84, 0x90, 0x27, 0xe4,
+			0x0c, 0xc4, 0x5e, 0x2b, 0xce, 0xe4, 0x03, 0x3f, 0xc0, 0x85,
+			0x33, 0x2f, 0xd9, 0x89, 0xa8, 0x39, 0x7f, 0xb5, 0x53, 0x9d,
+			0x3e, 0x26, 0xff, 0x2c, 0xf6, 0xb2, 0x32, 0x83, 0x57, 0x31,
+			0x56, 0x97, 0xcf, 0xd1, 0xde, 0xf0, 0x05, 0xd4, 0x64, 0xb7,
+			0x07, 0xc2, 0x9f, 0x93, 0x52, 0x2f, 0xe8, 0x6e, 0xcc, 0x17,
+			0x34, 0x04, 0x2c, 0x66, 0x2d, 0x82, 0x93, 0x1a, 0x50, 0xb6,
+			0x0d, 0x0f, 0xde, 0x02, 0xf7, 0x88, 0x0d, 0x1b, 0xa9, 0x5c,
+			0x0a, 0x98, 0x93, 0x1c, 0xd3, 0xcf, 0x34, 0xd5, 0xa4, 0xc2,
+			0x39, 0x19, 0xc9, 0x43, 0xe7, 0xa9, 0x93, 0x29, 0x85, 0x97,
+			0xa3, 0x6e, 0x3a, 0xa2, 0x2f, 0x88, 0x7f, 0x63, 0xa9, 0xef,
+			0x62, 0x70, 0x28, 0x5b, 0xcd, 0x48, 0xd5, 0x04, 0x12, 0x84,
+			0xfc, 0x30, 0x2a, 0xda, 0x21, 0x74, 0x23, 0xc5, 0x5f, 0xb9,
+			0xf8, 0x22, 0x71, 0x57, 0x41, 0x3c, 0xfb, 0xea, 0xf8, 0xf7,
+			0x0e, 0xcc, 0x00, 0xa0, 0xe7, 0x12, 0x89, 0x63, 0xce, 0xf0,
+			0x52, 0xd2, 0x30, 0x9f, 0x01, 0x47, 0x00, 0xb0, 0x9b, 0x2e,
+			0xd5, 0xa0, 0x0a, 0xa0, 0x0b, 0xcd,
+		},
+		SharedKey: []byte{
+			0xb7, 0xf4, 0x9c, 0x83, 0xcd, 0x75, 0x52, 0xfb, 0xb1, 0xcb,
+			0xf9, 0xc6, 0x01, 0x23, 0x4e, 0x71, 0x54, 0xad, 0x46, 0xb9,
+			0x41, 0x18, 0xdf, 0x64, 0x57, 0xe8, 0x74, 0xad, 0xc9, 0xc3,
+			0x53, 0x24, 0xfc, 0x5b, 0xd2, 0x1f, 0xac, 0x06, 0x96, 0x44,
+			0x62, 0x8a, 0x66, 0x5b, 0xe4, 0x08, 0x5b, 0x46, 0xe1, 0x5c,
+			0x22, 0xf7, 0x17, 0xd4, 0x3d, 0x0b, 0xb0, 0xaa, 0x60, 0x4b,
+			0xa6, 0xaa, 0x49, 0xd4, 0xfb, 0x49, 0xf6, 0xcd, 0x6d, 0x92,
+			0x22, 0xff, 0xda, 0xa7, 0x55, 0x14, 0xf8, 0xc3, 0x0b, 0x7a,
+			0x01, 0xee, 0xf1, 0x72, 0xb0, 0xc6, 0xc8, 0xe1, 0x59, 0xbc,
+			0x79, 0xde, 0xdd, 0xde, 0xbd, 0x8a, 0x42, 0xf0, 0x53, 0xf8,
+			0xee, 0x92, 0x88, 0xd1, 0x8f, 0x22, 0xbd, 0xc1, 0xc1, 0xea,
+			0x74, 0xa5, 0xc8, 0x64, 0x84, 0x7b, 0x17, 0x61, 0xcb, 0xa8,
+			0x47, 0xbc, 0x15, 0x78, 0xf2, 0x01, 0x6e, 0xfe, 0xd7, 0x73,
+			0x70, 0xe1, 0x5e, 0xb8, 0x07, 0x1f, 0x70, 0x81, 0x0c, 0x2c,
+			0x7c, 0x69, 0xb4, 0x7b, 0x08, 0x57, 0x96, 0x91, 0xf1, 0x1b,
+			0xb9, 0x1b, 0x91, 0x93, 0xbe, 0xee, 0xa1, 0x28, 0xcc, 0x3c,
+			0x80, 0x8b, 0xff, 0xbd, 0xf3, 0xc8, 0x65, 0x89, 0xcb, 0xcc,
+			0xed, 0xc6, 0x41, 0xf8, 0x7c, 0x13, 0xa0, 0x4c, 0x3c, 0x09,
+			0x2e, 0x54, 0x2d, 0x29, 0x0f, 0x15, 0xbb, 0x96, 0x6c, 0xf7,
+			0x8d, 0xce, 0x33, 0x2a, 0xfc, 0xb1, 0x73, 0x29, 0xfb, 0x20,
+			0x6d, 0x3e, 0x85, 0xd6, 0x69, 0x7b, 0xa6, 0x2c, 0x4e, 0x42,
+			0x76, 0xae, 0x73, 0xa4, 0x8d, 0x53, 0x64, 0x32, 0x9d, 0x35,
+			0x77, 0x02, 0x80, 0xdc, 0x1d, 0xa1, 0x6a, 0x16, 0x64, 0x43,
+			0x4a, 0x29, 0xe9, 0x39, 0x2a, 0x77, 0x33, 0x00, 0xe3, 0xc9,
+			0x82, 0x88, 0xce, 0x07, 0x5d, 0x46, 0xfe, 0xe7, 0x16, 0x42,
+			0x78, 0x6a, 0x8c, 0x09, 0xbe, 0x92,
+		},
+	},
+}
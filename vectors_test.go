@@ -0,0 +1,77 @@
+// Written in 2013 by Dmitry Chestnykh.
+//
+// To the extent possible under law, the author have dedicated all copyright
+// and related and neighboring rights to this software to the public domain
+// worldwide. This software is distributed without any warranty.
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package dhgroup14
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func TestTestVectors(t *testing.T) {
+	// SHA-256 of all fields of all vectors concatenated in order,
+	// computed independently.
+	const expected = "41acf1a91c29859965227373be2593dc4761cca4df17a8e2e854e9249f5618d1"
+	vectors := TestVectors()
+	if len(vectors) != numTestVectors {
+		t.Fatalf("got %d vectors", len(vectors))
+	}
+	h := sha256.New()
+	for i, v := range vectors {
+		for _, b := range [][]byte{v.PrivateKeyA, v.PrivateKeyB, v.PublicKeyA, v.PublicKeyB, v.SharedKey} {
+			h.Write(b)
+		}
+		if i > 0 {
+			a := sha256.Sum256(fmt.Appendf(nil, "dhgroup14 test vector %d A", i))
+			b := sha256.Sum256(fmt.Appendf(nil, "dhgroup14 test vector %d B", i))
+			if !bytes.Equal(v.PrivateKeyA, a[:]) || !bytes.Equal(v.PrivateKeyB, b[:]) {
+				t.Fatalf("%d: wrong private keys", i)
+			}
+		}
+		// Compare live results against the embedded values.
+		publicKeyA, err := GeneratePublicKey(rand.Reader, v.PrivateKeyA)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if !bytes.Equal(publicKeyA, v.PublicKeyA) {
+			t.Fatalf("%d: wrong public key A", i)
+		}
+		publicKeyB, err := GeneratePublicKeyDeterministic(v.PrivateKeyB)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if !bytes.Equal(publicKeyB, v.PublicKeyB) {
+			t.Fatalf("%d: wrong public key B", i)
+		}
+		sharedKeyA, err := SharedKey(rand.Reader, v.PublicKeyB, v.PrivateKeyA)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		sharedKeyB, err := SharedKey(rand.Reader, v.PublicKeyA, v.PrivateKeyB)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if !bytes.Equal(sharedKeyA, v.SharedKey) || !bytes.Equal(sharedKeyB, v.SharedKey) {
+			t.Fatalf("%d: wrong shared key", i)
+		}
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != expected {
+		t.Fatalf("expected vectors hash %s, got %s", expected, got)
+	}
+	if !bytes.Equal(vectors[0].PublicKeyA, golden.publicKey1) || !bytes.Equal(vectors[0].SharedKey, golden.sharedKey) {
+		t.Fatalf("first vector differs from spiped vector")
+	}
+	// Each call returns new slices.
+	vectors[0].PrivateKeyA[0] ^= 1
+	if TestVectors()[0].PrivateKeyA[0] == vectors[0].PrivateKeyA[0] {
+		t.Fatalf("vectors share memory")
+	}
+}